@@ -0,0 +1,174 @@
+package cortx
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"os"
+	"sync"
+	"time"
+)
+
+// Audit log actions and results
+const (
+	AuditActionDeleteBucket    = "DeleteBucket"
+	AuditActionDeleteObjects   = "DeleteObjects"
+	AuditActionRemoveLegalHold = "RemoveLegalHold"
+
+	AuditResultSuccess = "success"
+	AuditResultPartial = "partial"
+	AuditResultFailed  = "failed"
+)
+
+// AuditLog appends a JSON line to `path` for every destructive action the provider performs. A nil
+// *AuditLog (no `audit_log_path` configured) records nothing.
+type AuditLog struct {
+	path     string
+	endpoint string
+	identity string
+
+	mu sync.Mutex
+}
+
+// auditRecord -
+type auditRecord struct {
+	Timestamp string         `json:"timestamp"`
+	Endpoint  string         `json:"endpoint"`
+	Identity  string         `json:"identity"`
+	Action    string         `json:"action"`
+	Bucket    string         `json:"bucket"`
+	Objects   []*auditObject `json:"objects,omitempty"`
+	Result    string         `json:"result"`
+	Error     string         `json:"error,omitempty"`
+}
+
+// auditObject - An object version affected by an action, w. the per-object error (if any)
+type auditObject struct {
+	Key       string `json:"key"`
+	VersionID string `json:"version_id,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// NewAuditLog - Checks up front that the log can be appended to, s.t. a misconfigured path fails
+// at provider configuration rather than after objects have been deleted
+func NewAuditLog(path, endpoint, identity string) (*AuditLog, error) {
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("opening audit log (%s): %w", path, err)
+	}
+
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("opening audit log (%s): %w", path, err)
+	}
+
+	return &AuditLog{path: path, endpoint: endpoint, identity: identity}, nil
+}
+
+// RecordDeleteBucket -
+func (a *AuditLog) RecordDeleteBucket(bucket string, err error) error {
+	return a.record(AuditActionDeleteBucket, bucket, nil, err)
+}
+
+// RecordDeleteObjects - Records a DeleteObjects batch, objects listed in `output.Errors` are
+// recorded w. their error and make the result partial
+func (a *AuditLog) RecordDeleteObjects(bucket string, toDelete []*s3.ObjectIdentifier, output *s3.DeleteObjectsOutput, err error) error {
+
+	if a == nil {
+		return nil
+	}
+
+	failed := map[string]*s3.Error{}
+	if output != nil {
+		for _, v := range output.Errors {
+			failed[aws.StringValue(v.Key)+"@"+aws.StringValue(v.VersionId)] = v
+		}
+	}
+
+	objects := make([]*auditObject, 0, len(toDelete))
+	for _, v := range toDelete {
+		object := &auditObject{
+			Key:       aws.StringValue(v.Key),
+			VersionID: aws.StringValue(v.VersionId),
+		}
+
+		if e, ok := failed[object.Key+"@"+object.VersionID]; ok {
+			object.Error = fmt.Sprintf("%s: %s", aws.StringValue(e.Code), aws.StringValue(e.Message))
+		}
+
+		objects = append(objects, object)
+	}
+
+	record := a.newRecord(AuditActionDeleteObjects, bucket, objects, err)
+	if err == nil && len(failed) > 0 {
+		record.Result = AuditResultPartial
+	}
+
+	return a.write(record)
+}
+
+// RecordRemoveLegalHold -
+func (a *AuditLog) RecordRemoveLegalHold(bucket, key, versionID string, err error) error {
+	return a.record(AuditActionRemoveLegalHold, bucket, []*auditObject{{Key: key, VersionID: versionID}}, err)
+}
+
+// record -
+func (a *AuditLog) record(action, bucket string, objects []*auditObject, err error) error {
+
+	if a == nil {
+		return nil
+	}
+
+	return a.write(a.newRecord(action, bucket, objects, err))
+}
+
+// newRecord -
+func (a *AuditLog) newRecord(action, bucket string, objects []*auditObject, err error) *auditRecord {
+
+	record := &auditRecord{
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		Endpoint:  a.endpoint,
+		Identity:  a.identity,
+		Action:    action,
+		Bucket:    bucket,
+		Objects:   objects,
+		Result:    AuditResultSuccess,
+	}
+
+	if err != nil {
+		record.Result = AuditResultFailed
+		record.Error = err.Error()
+	}
+
+	return record
+}
+
+// write - Appends a single line, opening the file per record s.t. every record is on disk once
+// the action returns
+func (a *AuditLog) write(record *auditRecord) error {
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	f, err := os.OpenFile(a.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("writing audit log (%s): %w", a.path, err)
+	}
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("writing audit log (%s): %w", a.path, err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("writing audit log (%s): %w", a.path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package cortx
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAuditLogRecordDeleteObjects(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	audit, err := NewAuditLog(path, "http://cortx:28049", "AKIA")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	toDelete := []*s3.ObjectIdentifier{
+		{Key: aws.String("a"), VersionId: aws.String("1")},
+		{Key: aws.String("b"), VersionId: aws.String("2")},
+	}
+	output := &s3.DeleteObjectsOutput{
+		Errors: []*s3.Error{{Key: aws.String("b"), VersionId: aws.String("2"), Code: aws.String("AccessDenied"), Message: aws.String("Access Denied")}},
+	}
+
+	if err := audit.RecordDeleteObjects("bucket", toDelete, output, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := audit.RecordDeleteBucket("bucket", errors.New("BucketNotEmpty")); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(lines))
+	}
+
+	var objects auditRecord
+	if err := json.Unmarshal([]byte(lines[0]), &objects); err != nil {
+		t.Fatal(err)
+	}
+	if objects.Result != AuditResultPartial || objects.Objects[0].Error != "" || objects.Objects[1].Error == "" {
+		t.Errorf("unexpected DeleteObjects record: %s", lines[0])
+	}
+
+	var bucket auditRecord
+	if err := json.Unmarshal([]byte(lines[1]), &bucket); err != nil {
+		t.Fatal(err)
+	}
+	if bucket.Action != AuditActionDeleteBucket || bucket.Result != AuditResultFailed || bucket.Identity != "AKIA" {
+		t.Errorf("unexpected DeleteBucket record: %s", lines[1])
+	}
+
+	// No `audit_log_path`, nothing recorded
+	var disabled *AuditLog
+	if err := disabled.RecordDeleteBucket("bucket", nil); err != nil {
+		t.Errorf("nil audit log returned %v", err)
+	}
+}
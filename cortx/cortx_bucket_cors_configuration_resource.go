@@ -0,0 +1,340 @@
+package cortx
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/aws-sdk-go-base/v2/awsv1shim/v2/tfawserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"strings"
+	"time"
+)
+
+// corsAllowedMethods - HTTP methods accepted in a CORS rule, anything else is rejected by
+// PutBucketCors w. a (fairly unhelpful) MalformedXML error
+var corsAllowedMethods = []string{
+	"GET",
+	"PUT",
+	"HEAD",
+	"POST",
+	"DELETE",
+}
+
+// resourceBucketCorsConfiguration
+//
+// Standalone resource for managing a bucket's CORS rules, takes the place of the `cors_rule`
+// block that was removed from `resourceBucketUpdate`. Modeled on the AWS provider's
+// `aws_s3_bucket_cors_configuration`
+//
+// See: https://github.com/hashicorp/terraform-provider-aws/blob/main/internal/service/s3/bucket_cors_configuration.go
+func resourceBucketCorsConfiguration() *schema.Resource {
+
+	return &schema.Resource{
+		CreateContext: resourceBucketCorsConfigurationCreate,
+		ReadContext:   resourceBucketCorsConfigurationRead,
+		UpdateContext: resourceBucketCorsConfigurationUpdate,
+		DeleteContext: resourceBucketCorsConfigurationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(propagationTimeout),
+			Read:   schema.DefaultTimeout(propagationTimeout),
+			Update: schema.DefaultTimeout(propagationTimeout),
+			Delete: schema.DefaultTimeout(propagationTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 63),
+			},
+			"cors_rule": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MaxItems: 100,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"allowed_headers": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"allowed_methods": {
+							Type:     schema.TypeSet,
+							Required: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(corsAllowedMethods, false),
+							},
+						},
+						"allowed_origins": {
+							Type:     schema.TypeSet,
+							Required: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validateCorsAllowedOrigin,
+							},
+						},
+						"expose_headers": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"id": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringLenBetween(0, 255),
+						},
+						"max_age_seconds": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// resourceBucketCorsConfigurationCreate -
+func resourceBucketCorsConfigurationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	client := meta.(*CortxClient).S3
+	bucket := d.Get("bucket").(string)
+
+	if err := resourceBucketInternalCorsConfigurationPut(ctx, client, bucket, d.Get("cors_rule").(*schema.Set).List(), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return append(diags, OperationErrorDiagnostic("PutBucketCors", bucket, err))
+	}
+
+	d.SetId(bucket)
+	return resourceBucketCorsConfigurationRead(ctx, d, meta)
+}
+
+// resourceBucketCorsConfigurationRead -
+func resourceBucketCorsConfigurationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	var (
+		diags  diag.Diagnostics
+		output *s3.GetBucketCorsOutput
+	)
+
+	client := meta.(*CortxClient).S3
+
+	getBucketCorsInp := &s3.GetBucketCorsInput{
+		Bucket: aws.String(d.Id()),
+	}
+
+	// CORS configuration may not be visible immediately after PutBucketCors, only retry
+	// NotFound-ish errors on a new resource
+	err := resource.Retry(d.Timeout(schema.TimeoutRead), func() *resource.RetryError {
+
+		var err error
+		output, err = client.GetBucketCorsWithContext(ctx, getBucketCorsInp)
+
+		if d.IsNewResource() && tfawserr.ErrCodeEquals(err, s3.ErrCodeNoSuchBucket, ErrCodeNoSuchCORSConfiguration) {
+			return resource.RetryableError(err)
+		}
+
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		return nil
+	})
+
+	if TimedOut(err) {
+		output, err = client.GetBucketCorsWithContext(ctx, getBucketCorsInp)
+	}
+
+	// Configuration (or Bucket) Removed Outside of Terraform - Remove From State
+	if !d.IsNewResource() && tfawserr.ErrCodeEquals(err, s3.ErrCodeNoSuchBucket, ErrCodeNoSuchCORSConfiguration) {
+		log.Printf("[WARN] CORS Configuration for Bucket (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return diags
+	}
+
+	if err != nil {
+		return append(diags, OperationErrorDiagnostic("GetBucketCors", d.Id(), err))
+	}
+
+	d.Set("bucket", d.Id())
+
+	if err := d.Set("cors_rule", flattenBucketCorsRules(output.CORSRules)); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("[ERROR] Failed setting cors_rule (%s):", d.Id()),
+			Detail:   fmt.Sprintf("[ERROR] %v", err),
+		})
+		return diags
+	}
+
+	return diags
+}
+
+// resourceBucketCorsConfigurationUpdate -
+func resourceBucketCorsConfigurationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	client := meta.(*CortxClient).S3
+
+	if d.HasChange("cors_rule") {
+		if err := resourceBucketInternalCorsConfigurationPut(ctx, client, d.Id(), d.Get("cors_rule").(*schema.Set).List(), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return append(diags, OperationErrorDiagnostic("PutBucketCors", d.Id(), err))
+		}
+	}
+
+	return resourceBucketCorsConfigurationRead(ctx, d, meta)
+}
+
+// resourceBucketCorsConfigurationDelete -
+func resourceBucketCorsConfigurationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	client := meta.(*CortxClient).S3
+
+	_, err := client.DeleteBucketCorsWithContext(ctx, &s3.DeleteBucketCorsInput{
+		Bucket: aws.String(d.Id()),
+	})
+
+	// Configuration (or Bucket) Already Gone - Successful "Delete"
+	if tfawserr.ErrCodeEquals(err, s3.ErrCodeNoSuchBucket, ErrCodeNoSuchCORSConfiguration) {
+		return diags
+	}
+
+	if err != nil {
+		return append(diags, OperationErrorDiagnostic("DeleteBucketCors", d.Id(), err))
+	}
+
+	return diags
+}
+
+// resourceBucketInternalCorsConfigurationPut - Puts the full set of CORS rules, retries while
+// a newly created bucket propagates
+func resourceBucketInternalCorsConfigurationPut(ctx context.Context, client *s3.S3, bucket string, rules []interface{}, timeout time.Duration) error {
+	_, err := RetryWhenAWSErrCodeEqualsContext(
+		ctx,
+		timeout,
+		func() (interface{}, error) {
+			return client.PutBucketCorsWithContext(ctx, &s3.PutBucketCorsInput{
+				Bucket: aws.String(bucket),
+				CORSConfiguration: &s3.CORSConfiguration{
+					CORSRules: expandBucketCorsRules(rules),
+				},
+			})
+		},
+		s3.ErrCodeNoSuchBucket,
+	)
+	return err
+}
+
+// validateCorsAllowedOrigin - An origin is either the bare wildcard `*` or an http(s) origin
+// w. at most one `*` wildcard (e.g. https://*.example.com)
+func validateCorsAllowedOrigin(i interface{}, k string) (warnings []string, errors []error) {
+
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return warnings, errors
+	}
+
+	if v == "*" {
+		return warnings, errors
+	}
+
+	if strings.Count(v, "*") > 1 {
+		errors = append(errors, fmt.Errorf("%q may contain at most one wildcard (*), got: %s", k, v))
+	}
+
+	host := strings.TrimPrefix(strings.TrimPrefix(v, "https://"), "http://")
+	if host == v {
+		errors = append(errors, fmt.Errorf("%q must be `*` or begin w. http:// or https://, got: %s", k, v))
+		return warnings, errors
+	}
+
+	if host == "" || strings.ContainsAny(host, "/?# ") {
+		errors = append(errors, fmt.Errorf("%q must be an origin (scheme, host, and optional port), got: %s", k, v))
+	}
+
+	return warnings, errors
+}
+
+// expandBucketCorsRules - Converts `cors_rule` blocks to CORTX API rules
+func expandBucketCorsRules(l []interface{}) []*s3.CORSRule {
+
+	var rules []*s3.CORSRule
+
+	for _, tfMapRaw := range l {
+		tfMap, ok := tfMapRaw.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		rule := &s3.CORSRule{}
+
+		if v, ok := tfMap["allowed_headers"].(*schema.Set); ok && v.Len() > 0 {
+			rule.AllowedHeaders = ExpandStringSet(v)
+		}
+
+		if v, ok := tfMap["allowed_methods"].(*schema.Set); ok && v.Len() > 0 {
+			rule.AllowedMethods = ExpandStringSet(v)
+		}
+
+		if v, ok := tfMap["allowed_origins"].(*schema.Set); ok && v.Len() > 0 {
+			rule.AllowedOrigins = ExpandStringSet(v)
+		}
+
+		if v, ok := tfMap["expose_headers"].(*schema.Set); ok && v.Len() > 0 {
+			rule.ExposeHeaders = ExpandStringSet(v)
+		}
+
+		if v, ok := tfMap["id"].(string); ok && v != "" {
+			rule.ID = aws.String(v)
+		}
+
+		if v, ok := tfMap["max_age_seconds"].(int); ok && v > 0 {
+			rule.MaxAgeSeconds = aws.Int64(int64(v))
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
+
+// flattenBucketCorsRules - Converts CORTX API rules to `cors_rule` blocks
+func flattenBucketCorsRules(rules []*s3.CORSRule) []interface{} {
+
+	var results []interface{}
+
+	for _, rule := range rules {
+		if rule == nil {
+			continue
+		}
+
+		m := map[string]interface{}{
+			"allowed_headers": FlattenStringSet(rule.AllowedHeaders),
+			"allowed_methods": FlattenStringSet(rule.AllowedMethods),
+			"allowed_origins": FlattenStringSet(rule.AllowedOrigins),
+			"expose_headers":  FlattenStringSet(rule.ExposeHeaders),
+			"id":              aws.StringValue(rule.ID),
+			"max_age_seconds": int(aws.Int64Value(rule.MaxAgeSeconds)),
+		}
+
+		results = append(results, m)
+	}
+
+	return results
+}
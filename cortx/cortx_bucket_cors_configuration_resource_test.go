@@ -0,0 +1,33 @@
+package cortx
+
+import (
+	"testing"
+)
+
+func TestValidateCorsAllowedOrigin(t *testing.T) {
+
+	cases := []struct {
+		origin string
+		valid  bool
+	}{
+		{"*", true},
+		{"http://localhost:3000", true},
+		{"https://app.example.com", true},
+		{"https://*.example.com", true},
+		{"https://*.*.example.com", false},
+		{"example.com", false},
+		{"ftp://example.com", false},
+		{"https://", false},
+		{"https://example.com/upload", false},
+	}
+
+	for _, tc := range cases {
+		_, errs := validateCorsAllowedOrigin(tc.origin, "allowed_origins")
+		if tc.valid && len(errs) > 0 {
+			t.Errorf("expected %q to be valid, got: %v", tc.origin, errs)
+		}
+		if !tc.valid && len(errs) == 0 {
+			t.Errorf("expected %q to be invalid", tc.origin)
+		}
+	}
+}
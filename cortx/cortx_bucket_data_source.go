@@ -4,22 +4,28 @@ import (
 	"context"
 	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/hashicorp/aws-sdk-go-base/v2/awsv1shim/v2/tfawserr"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"log"
+	"net/url"
+	"time"
 )
 
 // datasourceBucket
 //
-// Uses the official AWS compatible definition for an S3 bucket
-// Updated as of 6/15/2022 (Hash: 15d4edf98b4fb6c40a73397ee7504f6e61ec3574)
+// Describes a bucket as seen by the CORTX server - endpoint URLs, versioning, object lock, tags,
+// and policy. Lookups the server doesn't implement leave their attributes empty
 //
-// See: https://github.com/hashicorp/terraform-provider-aws/blob/main/internal/service/s3/bucket_data_source.go
+// The AWS-only attributes (`bucket_domain_name`, `bucket_regional_domain_name`, `hosted_zone_id`,
+// `website_endpoint`, `website_domain`) are kept for compatibility w. the AWS provider's
+// `aws_s3_bucket`, and are only set when the bucket's region is an AWS region
 //
+// See: https://github.com/hashicorp/terraform-provider-aws/blob/main/internal/service/s3/bucket_data_source.go
 func datasourceBucket() *schema.Resource {
 
 	return &schema.Resource{
@@ -33,15 +39,19 @@ func datasourceBucket() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
-			"bucket_domain_name": {
+			"endpoint": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
-			"bucket_regional_domain_name": {
+			"path_style_url": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
-			"hosted_zone_id": {
+			"virtual_hosted_style_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"creation_date": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
@@ -49,6 +59,59 @@ func datasourceBucket() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"versioning_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"object_lock_enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"object_lock_rule": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"default_retention": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"mode": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"days": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"years": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"tags": TagsSchemaComputed(),
+			"has_policy": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"bucket_domain_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"bucket_regional_domain_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"hosted_zone_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"website_endpoint": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -64,100 +127,259 @@ func datasourceBucket() *schema.Resource {
 func datasourceBucketRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	var diags diag.Diagnostics
-	client := meta.(*s3.S3)
+
+	conn := meta.(*CortxClient)
+	client := conn.S3
 
 	// Get user provided bucket name and validate existence
 	bucket := d.Get("bucket").(string)
 
-	_, err := client.HeadBucket(
-		&s3.HeadBucketInput{
-			Bucket: aws.String(bucket),
-		},
-	)
+	_, err := client.HeadBucketWithContext(ctx, &s3.HeadBucketInput{
+		Bucket: aws.String(bucket),
+	})
 
 	// Failed to Get Bucket - Return Diagnostics
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  fmt.Sprintf("[ERROR] Failed on GetHeadBucket (%s):", bucket),
-			Detail:   fmt.Sprintf("[ERROR] %w", err),
+			Detail:   fmt.Sprintf("[ERROR] %v", err),
 		})
 		return diags
 	}
 
 	// Set DataSource Attributes - Name, ID, ARN, Location, etc.
 	d.SetId(bucket)
+	d.Set("arn", bucketARN(bucket))
 
-	// Synthetic ARN using CORTX as provider Partition
-	d.Set("arn", arn.ARN{
-		Partition: endpoints.AwsPartition().ID(),
-		Service:   "s3",
-		Resource:  bucket,
-	}.String())
-
-	// FROM AWS PROVIDER SOURCE: By default, GetBucketRegion forces virtual host
-	// addressing, which is not compatible with many non-AWS implementations. Instead,
-	// pass the provider s3_force_path_style configuration, which defaults to false
-	region, err := s3manager.GetBucketRegionWithClient(context.Background(), client, bucket, func(r *request.Request) {
-		r.Config.S3ForcePathStyle = client.Config.S3ForcePathStyle
-		r.Config.Credentials = client.Config.Credentials
-	})
+	// Endpoint URLs - Requests are signed path style, the virtual hosted URL is for clients that
+	// resolve `<bucket>.<host>` (e.g. behind a wildcard DNS record)
+	pathStyleURL, virtualHostedURL, err := bucketURLs(conn.Endpoint, bucket)
 
-	// Region
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
-			Summary:  fmt.Sprintf("[ERROR] Failed on GetBucketRegionwithClient (%s):", bucket),
-			Detail:   fmt.Sprintf("[ERROR] %w", err),
+			Summary:  fmt.Sprintf("[ERROR] Failed parsing endpoint (%s):", conn.Endpoint),
+			Detail:   fmt.Sprintf("[ERROR] %v", err),
 		})
 		return diags
 	}
 
-	d.Set("region", region)
+	d.Set("endpoint", conn.Endpoint)
+	d.Set("path_style_url", pathStyleURL)
+	d.Set("virtual_hosted_style_url", virtualHostedURL)
 
-	// Hosted Zone
-	if hostedZoneID, ok := hostedZoneIDsMap[region]; !ok {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  fmt.Sprintf("[ERROR] Failed on GetBucketHostedZone (%s):", bucket),
-			Detail:   fmt.Sprintf("[ERROR] %w", err),
+	// Each lookup below is independent, one failing doesn't stop the others
+	for _, read := range []func(context.Context, *s3.S3, *schema.ResourceData) diag.Diagnostics{
+		datasourceBucketInternalCreationDateRead,
+		datasourceBucketInternalRegionRead,
+		datasourceBucketInternalVersioningRead,
+		datasourceBucketInternalObjectLockRead,
+		datasourceBucketInternalTagsRead,
+		datasourceBucketInternalPolicyRead,
+	} {
+		diags = append(diags, read(ctx, client, d)...)
+	}
+
+	return diags
+}
+
+// datasourceBucketInternalCreationDateRead - Only ListBuckets returns the creation date, and only
+// for the caller's own buckets
+func datasourceBucketInternalCreationDateRead(ctx context.Context, client *s3.S3, d *schema.ResourceData) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	output, err := client.ListBucketsWithContext(ctx, &s3.ListBucketsInput{})
+
+	if err != nil {
+		return append(diags, OperationErrorDiagnostic("ListBuckets", d.Id(), err))
+	}
+
+	d.Set("creation_date", "")
+
+	for _, b := range output.Buckets {
+		if aws.StringValue(b.Name) == d.Id() {
+			d.Set("creation_date", aws.TimeValue(b.CreationDate).Format(time.RFC3339))
+			break
+		}
+	}
+
+	return diags
+}
+
+// datasourceBucketInternalRegionRead - The region and the AWS-only attributes derived from it, the
+// AWS-only attributes are never fatal
+func datasourceBucketInternalRegionRead(ctx context.Context, client *s3.S3, d *schema.ResourceData) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	bucket := d.Id()
+
+	// Region - The bucket's location constraint, falls back to GetBucketRegion (which
+	// probes the `x-amz-bucket-region` header) on servers w/o GetBucketLocation
+	region, err := findBucketRegion(ctx, client, bucket)
+
+	if NotSupportedByServer(err) {
+		// FROM AWS PROVIDER SOURCE: By default, GetBucketRegion forces virtual host
+		// addressing, which is not compatible with many non-AWS implementations. Instead,
+		// pass the provider s3_force_path_style configuration, which defaults to false
+		region, err = s3manager.GetBucketRegionWithClient(ctx, client, bucket, func(r *request.Request) {
+			r.Config.S3ForcePathStyle = client.Config.S3ForcePathStyle
+			r.Config.Credentials = client.Config.Credentials
 		})
+	}
+
+	if err != nil {
+		log.Printf("[WARN] Unable to read region of Bucket (%s): %v", bucket, err)
+		return diags
+	}
+
+	d.Set("region", region)
+
+	// AWS-Only - Hosted zones, domain names, and website endpoints only exist for AWS regions
+	hostedZoneID, ok := hostedZoneIDsMap[region]
+	if !ok {
+		log.Printf("[DEBUG] Bucket (%s) region (%s) is not an AWS region, skipping AWS-only attributes", bucket, region)
 		return diags
-	} else {
-		d.Set("hosted_zone_id", hostedZoneID)
-	}
-
-	// Website Endpoint Params - Hypothetical, as of 6/15/2022 - the following requests
-	// would fail on CORTX with MethodNotAllowed, see the full list @
-	// https://seagate-systems.atlassian.net/wiki/spaces/PUB/pages/759333066/CORTX+S3+API+Guide
-	//
-	// aws s3api get-bucket-website --bucket test-bucket --profile cloudshare \
-	//	--endpoint-url http://$PATH.vm.cld.sr:31949
-	//
-	// aws s3 website s3://test-bucket --profile cloudshare \
-	//	--endpoint-url http://$PATH.vm.cld.sr:31949
-	//
-	//
+	}
+
+	d.Set("hosted_zone_id", hostedZoneID)
+	d.Set("bucket_domain_name", fmt.Sprintf("%s.s3.%s", bucket, endpoints.AwsPartition().DNSSuffix()))
 
+	if regionalDomainName, err := BucketRegionalDomainName(bucket, region); err == nil {
+		d.Set("bucket_regional_domain_name", regionalDomainName)
+	}
+
+	// NOTE: CORTX answers the website APIs w. MethodNotAllowed (as of 6/15/2022), these are
+	// the endpoints the bucket would have on AWS
 	domain := fmt.Sprintf("s3-website.%s.%s", region, endpoints.AwsPartition().DNSSuffix())
+	d.Set("website_endpoint", fmt.Sprintf("%s.%s", bucket, domain))
+	d.Set("website_domain", domain)
 
-	if err := d.Set("website_endpoint", fmt.Sprintf("%s.%s", bucket, domain)); err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  fmt.Sprintf("[ERROR] Failed on GetWebsiteEndpoint (%s):", bucket),
-			Detail:   fmt.Sprintf("[ERROR] %w", err),
-		})
+	return diags
+}
+
+// datasourceBucketInternalVersioningRead - `Enabled`, `Suspended`, or empty for buckets that never
+// had versioning configured
+func datasourceBucketInternalVersioningRead(ctx context.Context, client *s3.S3, d *schema.ResourceData) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	output, err := client.GetBucketVersioningWithContext(ctx, &s3.GetBucketVersioningInput{
+		Bucket: aws.String(d.Id()),
+	})
+
+	if NotSupportedByServer(err) {
+		log.Printf("[WARN] Unable to read versioning of Bucket (%s): %v", d.Id(), err)
 		return diags
 	}
 
-	if err := d.Set("website_domain", domain); err != nil {
+	if err != nil {
+		return append(diags, OperationErrorDiagnostic("GetBucketVersioning", d.Id(), err))
+	}
+
+	d.Set("versioning_status", output.Status)
+
+	return diags
+}
+
+// datasourceBucketInternalObjectLockRead -
+func datasourceBucketInternalObjectLockRead(ctx context.Context, client *s3.S3, d *schema.ResourceData) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	output, err := client.GetObjectLockConfigurationWithContext(ctx, &s3.GetObjectLockConfigurationInput{
+		Bucket: aws.String(d.Id()),
+	})
+
+	if NotSupportedByServer(err) {
+		log.Printf("[WARN] Unable to read object lock configuration of Bucket (%s): %v", d.Id(), err)
+		return diags
+	}
+
+	if err != nil && !tfawserr.ErrCodeEquals(err, ErrCodeObjectLockConfigurationNotFound) {
+		return append(diags, OperationErrorDiagnostic("GetObjectLockConfiguration", d.Id(), err))
+	}
+
+	d.Set("object_lock_enabled", aws.StringValue(objectLockEnabledStatus(output)) == s3.ObjectLockEnabledEnabled)
+
+	var rule []interface{}
+	if output != nil {
+		rule = flattenBucketObjectLockRule(output.ObjectLockConfiguration)
+	}
+
+	if err := d.Set("object_lock_rule", rule); err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
-			Summary:  fmt.Sprintf("[ERROR] Failed on GetWebsiteEndpoint (%s):", bucket),
-			Detail:   fmt.Sprintf("[ERROR] %w", err),
+			Summary:  fmt.Sprintf("[ERROR] Failed setting object_lock_rule (%s):", d.Id()),
+			Detail:   fmt.Sprintf("[ERROR] %v", err),
 		})
 		return diags
 	}
 
 	return diags
 }
+
+// datasourceBucketInternalTagsRead -
+func datasourceBucketInternalTagsRead(ctx context.Context, client *s3.S3, d *schema.ResourceData) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	tags, err := findBucketTags(ctx, client, d.Id())
+
+	if NotSupportedByServer(err) {
+		log.Printf("[WARN] Unable to read tags of Bucket (%s): %v", d.Id(), err)
+		return diags
+	}
+
+	if err != nil {
+		return append(diags, OperationErrorDiagnostic("GetBucketTagging", d.Id(), err))
+	}
+
+	d.Set("tags", PointersMapToStringList(tags))
+
+	return diags
+}
+
+// datasourceBucketInternalPolicyRead - Only whether a policy is attached, not the policy itself
+func datasourceBucketInternalPolicyRead(ctx context.Context, client *s3.S3, d *schema.ResourceData) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	_, err := client.GetBucketPolicyWithContext(ctx, &s3.GetBucketPolicyInput{
+		Bucket: aws.String(d.Id()),
+	})
+
+	if NotSupportedByServer(err) {
+		log.Printf("[WARN] Unable to read policy of Bucket (%s): %v", d.Id(), err)
+		return diags
+	}
+
+	if err != nil && !tfawserr.ErrCodeEquals(err, ErrCodeNoSuchBucketPolicy) {
+		return append(diags, OperationErrorDiagnostic("GetBucketPolicy", d.Id(), err))
+	}
+
+	d.Set("has_policy", err == nil)
+
+	return diags
+}
+
+// bucketURLs - Path style (`<endpoint>/<bucket>`) and virtual hosted style (`<bucket>.<host>`)
+// URLs of a bucket on the given endpoint
+func bucketURLs(endpoint, bucket string) (string, string, error) {
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", "", err
+	}
+
+	pathStyle := *u
+	pathStyle.Path = "/" + bucket
+
+	virtualHosted := *u
+	virtualHosted.Host = bucket + "." + u.Host
+	virtualHosted.Path = "/"
+
+	return pathStyle.String(), virtualHosted.String(), nil
+}
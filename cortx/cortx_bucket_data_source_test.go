@@ -0,0 +1,21 @@
+package cortx
+
+import (
+	"testing"
+)
+
+func TestBucketURLs(t *testing.T) {
+
+	pathStyle, virtualHosted, err := bucketURLs("http://cortx.local:31949", "my-bucket")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if pathStyle != "http://cortx.local:31949/my-bucket" {
+		t.Errorf("unexpected path style URL: %s", pathStyle)
+	}
+
+	if virtualHosted != "http://my-bucket.cortx.local:31949/" {
+		t.Errorf("unexpected virtual hosted style URL: %s", virtualHosted)
+	}
+}
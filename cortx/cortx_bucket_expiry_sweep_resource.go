@@ -0,0 +1,435 @@
+package cortx
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/aws-sdk-go-base/v2/awsv1shim/v2/tfawserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// maxDeleteObjectsBatchSize - DeleteObjects accepts at most 1000 keys per request
+const maxDeleteObjectsBatchSize = 1000
+
+// expirySweepCandidate - An object version selected for deletion by a sweep
+type expirySweepCandidate struct {
+	Key          string
+	VersionID    string
+	LastModified time.Time
+	Size         int64
+}
+
+// resourceBucketExpirySweep
+//
+// Provider-side stand-in for lifecycle expiration on CORTX releases that reject the lifecycle
+// APIs. Every apply lists the objects under `prefixes` and deletes the ones that are older than
+// `older_than_days` AND carry every tag in `tags` (whichever of the two are set). The resource
+// always plans an update s.t. the sweep re-runs on each apply, destroying the resource only
+// removes it from state.
+//
+// `matched_objects` lists at most `max_deletions` of the matches, `matched_count` counts all of them.
+//
+// By default only current objects are considered and deleted by key (on a versioned bucket
+// this leaves a delete marker), w. `all_versions` every matching version is permanently deleted
+// by version ID. Object lock configurations are always respected.
+func resourceBucketExpirySweep() *schema.Resource {
+
+	return &schema.Resource{
+		CreateContext: resourceBucketExpirySweepCreate,
+		ReadContext:   resourceBucketExpirySweepRead,
+		UpdateContext: resourceBucketExpirySweepUpdate,
+		DeleteContext: resourceBucketExpirySweepDelete,
+		CustomizeDiff: resourceBucketExpirySweepCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(expirySweepTimeout),
+			Read:   schema.DefaultTimeout(propagationTimeout),
+			Update: schema.DefaultTimeout(expirySweepTimeout),
+			Delete: schema.DefaultTimeout(propagationTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 63),
+			},
+			"prefixes": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"older_than_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				AtLeastOneOf: []string{"older_than_days", "tags"},
+			},
+			"tags": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				AtLeastOneOf: []string{"older_than_days", "tags"},
+			},
+			"all_versions": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"dry_run": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"max_deletions": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1000,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"matched_objects": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"last_modified": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"matched_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"deleted_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"swept_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// resourceBucketExpirySweepCustomizeDiff - Marks the sweep outputs as unknown on every plan,
+// this is what makes the sweep run on each apply
+func resourceBucketExpirySweepCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+
+	if d.Id() == "" {
+		return nil
+	}
+
+	for _, k := range []string{"matched_objects", "matched_count", "deleted_count", "swept_at"} {
+		if err := d.SetNewComputed(k); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resourceBucketExpirySweepCreate -
+func resourceBucketExpirySweepCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	bucket := d.Get("bucket").(string)
+
+	if diags := resourceBucketInternalExpirySweep(ctx, d, meta.(*CortxClient), bucket); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(bucket)
+	return resourceBucketExpirySweepRead(ctx, d, meta)
+}
+
+// resourceBucketExpirySweepRead - The sweep has no server side state of its own, only check that
+// the bucket still exists
+func resourceBucketExpirySweepRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	client := meta.(*CortxClient).S3
+
+	_, err := client.HeadBucketWithContext(ctx, &s3.HeadBucketInput{
+		Bucket: aws.String(d.Id()),
+	})
+
+	// Bucket Removed Outside of Terraform - Remove From State
+	if !d.IsNewResource() && (tfawserr.ErrCodeEquals(err, s3.ErrCodeNoSuchBucket) || tfawserr.ErrStatusCodeEquals(err, http.StatusNotFound)) {
+		log.Printf("[WARN] Bucket (%s) for expiry sweep not found, removing from state", d.Id())
+		d.SetId("")
+		return diags
+	}
+
+	if err != nil {
+		return append(diags, OperationErrorDiagnostic("HeadBucket", d.Id(), err))
+	}
+
+	d.Set("bucket", d.Id())
+
+	return diags
+}
+
+// resourceBucketExpirySweepUpdate - Runs on every apply, see resourceBucketExpirySweepCustomizeDiff
+func resourceBucketExpirySweepUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	if diags := resourceBucketInternalExpirySweep(ctx, d, meta.(*CortxClient), d.Id()); diags.HasError() {
+		return diags
+	}
+
+	return resourceBucketExpirySweepRead(ctx, d, meta)
+}
+
+// resourceBucketExpirySweepDelete - Removing the sweep doesn't touch the bucket's objects
+func resourceBucketExpirySweepDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return nil
+}
+
+// resourceBucketInternalExpirySweep - Collects every candidate before deleting anything s.t. the
+// `max_deletions` guard can refuse the sweep as a whole
+func resourceBucketInternalExpirySweep(ctx context.Context, d *schema.ResourceData, conn *CortxClient, bucket string) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	candidates, err := listExpirySweepCandidates(ctx, conn.S3, bucket, d)
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("[ERROR] Failed listing expired objects (%s):", bucket),
+			Detail:   fmt.Sprintf("[ERROR] %v", err),
+		})
+		return diags
+	}
+
+	// Only the first `max_deletions` matches are kept in state, a larger sweep is refused anyway
+	d.Set("matched_objects", flattenExpirySweepCandidates(candidates, d.Get("max_deletions").(int)))
+	d.Set("matched_count", len(candidates))
+	d.Set("swept_at", time.Now().UTC().Format(time.RFC3339))
+
+	if d.Get("dry_run").(bool) {
+		log.Printf("[INFO] Expiry sweep (%s) dry run matched %d objects", bucket, len(candidates))
+		d.Set("deleted_count", 0)
+		return diags
+	}
+
+	if maxDeletions := d.Get("max_deletions").(int); len(candidates) > maxDeletions {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("[ERROR] Expiry sweep (%s) exceeds max_deletions:", bucket),
+			Detail: fmt.Sprintf(
+				"[ERROR] %d objects matched the sweep, more than max_deletions (%d). Nothing was deleted, "+
+					"run w. dry_run = true to review the matches or raise max_deletions", len(candidates), maxDeletions,
+			),
+		})
+		return diags
+	}
+
+	nDeleted, err := deleteExpirySweepCandidates(ctx, conn.S3, conn.AuditLog, bucket, candidates, d.Get("all_versions").(bool))
+	d.Set("deleted_count", nDeleted)
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("[ERROR] Failed deleting expired objects (%s):", bucket),
+			Detail:   fmt.Sprintf("[ERROR] deleted %d of %d objects: %v", nDeleted, len(candidates), err),
+		})
+		return diags
+	}
+
+	log.Printf("[INFO] Expiry sweep (%s) deleted %d objects", bucket, nDeleted)
+	return diags
+}
+
+// listExpirySweepCandidates - Walks the object versions under each prefix and returns those that
+// match the sweep's age and tag filters, ordered by key
+func listExpirySweepCandidates(ctx context.Context, client *s3.S3, bucket string, d *schema.ResourceData) ([]*expirySweepCandidate, error) {
+
+	var (
+		cutoff      time.Time
+		allVersions = d.Get("all_versions").(bool)
+		tags        = d.Get("tags").(map[string]interface{})
+		seen        = map[string]*expirySweepCandidate{}
+		prefixes    = []string{""}
+	)
+
+	if v := d.Get("older_than_days").(int); v > 0 {
+		cutoff = time.Now().AddDate(0, 0, -v)
+	}
+
+	if v := d.Get("prefixes").(*schema.Set); v.Len() > 0 {
+		prefixes = nil
+		for _, p := range v.List() {
+			prefixes = append(prefixes, p.(string))
+		}
+	}
+
+	for _, prefix := range prefixes {
+		_, err := forEachObjectVersionsPage(ctx, client, bucket, prefix, func(ctx context.Context, client *s3.S3, bucket string, page *s3.ListObjectVersionsOutput) (int64, error) {
+			for _, v := range page.Versions {
+
+				if !allVersions && !aws.BoolValue(v.IsLatest) {
+					continue
+				}
+
+				if !cutoff.IsZero() && !aws.TimeValue(v.LastModified).Before(cutoff) {
+					continue
+				}
+
+				candidate := &expirySweepCandidate{
+					Key:          aws.StringValue(v.Key),
+					LastModified: aws.TimeValue(v.LastModified),
+					Size:         aws.Int64Value(v.Size),
+				}
+
+				// Current objects are deleted by key, only pin the version when sweeping all versions
+				if allVersions {
+					candidate.VersionID = aws.StringValue(v.VersionId)
+				}
+
+				id := candidate.Key + "@" + candidate.VersionID
+				if _, ok := seen[id]; ok {
+					continue // Overlapping prefixes
+				}
+
+				if len(tags) > 0 {
+					match, err := objectTagsMatch(ctx, client, bucket, candidate.Key, aws.StringValue(v.VersionId), tags)
+					if err != nil {
+						return 0, err
+					}
+					if !match {
+						continue
+					}
+				}
+
+				seen[id] = candidate
+			}
+			return 0, nil
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	candidates := make([]*expirySweepCandidate, 0, len(seen))
+	for _, c := range seen {
+		candidates = append(candidates, c)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Key == candidates[j].Key {
+			return candidates[i].LastModified.After(candidates[j].LastModified)
+		}
+		return candidates[i].Key < candidates[j].Key
+	})
+
+	return candidates, nil
+}
+
+// deleteExpirySweepCandidates - Deletes the candidates in DeleteObjects sized batches, never
+// bypassing object lock configurations
+func deleteExpirySweepCandidates(ctx context.Context, client *s3.S3, audit *AuditLog, bucket string, candidates []*expirySweepCandidate, allVersions bool) (int64, error) {
+
+	var nDeleted int64
+
+	for start := 0; start < len(candidates); start += maxDeleteObjectsBatchSize {
+
+		end := start + maxDeleteObjectsBatchSize
+		if end > len(candidates) {
+			end = len(candidates)
+		}
+
+		toDelete := make([]*s3.ObjectIdentifier, 0, end-start)
+		for _, c := range candidates[start:end] {
+			identifier := &s3.ObjectIdentifier{Key: aws.String(c.Key)}
+			if allVersions {
+				identifier.VersionId = aws.String(c.VersionID)
+			}
+			toDelete = append(toDelete, identifier)
+		}
+
+		n, err := deleteBatchOfObjectVersions(ctx, client, audit, bucket, toDelete, false)
+		nDeleted += n
+
+		if err != nil {
+			return nDeleted, err
+		}
+	}
+
+	return nDeleted, nil
+}
+
+// objectTagsMatch - Reports whether an object (version) carries every one of the given tags
+func objectTagsMatch(ctx context.Context, client *s3.S3, bucket, key, versionID string, tags map[string]interface{}) (bool, error) {
+
+	input := &s3.GetObjectTaggingInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	}
+
+	if versionID != "" && versionID != "null" {
+		input.VersionId = aws.String(versionID)
+	}
+
+	output, err := client.GetObjectTaggingWithContext(ctx, input)
+
+	if err != nil {
+		return false, fmt.Errorf("getting S3 object (%s) version (%s) tags: %w", key, versionID, err)
+	}
+
+	objectTags := make(map[string]string, len(output.TagSet))
+	for _, t := range output.TagSet {
+		objectTags[aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+	}
+
+	for k, v := range tags {
+		if value, ok := objectTags[k]; !ok || value != v.(string) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// flattenExpirySweepCandidates - The first `limit` candidates (in key order)
+func flattenExpirySweepCandidates(candidates []*expirySweepCandidate, limit int) []interface{} {
+
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	results := make([]interface{}, 0, len(candidates))
+
+	for _, c := range candidates {
+		results = append(results, map[string]interface{}{
+			"key":           c.Key,
+			"version_id":    c.VersionID,
+			"last_modified": c.LastModified.Format(time.RFC3339),
+			"size":          int(c.Size),
+		})
+	}
+
+	return results
+}
@@ -0,0 +1,29 @@
+package cortx
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFlattenExpirySweepCandidates(t *testing.T) {
+
+	now := time.Now()
+	candidates := []*expirySweepCandidate{
+		{Key: "a", LastModified: now, Size: 1},
+		{Key: "b", LastModified: now, Size: 2},
+		{Key: "c", LastModified: now, Size: 3},
+	}
+
+	cases := map[int]int{0: 0, 2: 2, 3: 3, 1000: 3}
+
+	for limit, expected := range cases {
+		if got := flattenExpirySweepCandidates(candidates, limit); len(got) != expected {
+			t.Errorf("limit %d: expected %d candidates, got %d", limit, expected, len(got))
+		}
+	}
+
+	got := flattenExpirySweepCandidates(candidates, 1)
+	if key := got[0].(map[string]interface{})["key"]; key != "a" {
+		t.Errorf("expected the first candidate to be kept, got %v", key)
+	}
+}
@@ -0,0 +1,642 @@
+package cortx
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/aws-sdk-go-base/v2/awsv1shim/v2/tfawserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// resourceBucketLifecycleConfiguration
+//
+// Standalone resource for managing a bucket's lifecycle rules, takes the place of the
+// `lifecycle_rule` block that was removed from `resourceBucketUpdate`. Modeled on the AWS
+// provider's `aws_s3_bucket_lifecycle_configuration`, the `filter` block is simplified to a
+// prefix and a map of tags (mapped onto Prefix, Tag, or And as needed)
+//
+// See: https://github.com/hashicorp/terraform-provider-aws/blob/main/internal/service/s3/bucket_lifecycle_configuration.go
+func resourceBucketLifecycleConfiguration() *schema.Resource {
+
+	return &schema.Resource{
+		CreateContext: resourceBucketLifecycleConfigurationCreate,
+		ReadContext:   resourceBucketLifecycleConfigurationRead,
+		UpdateContext: resourceBucketLifecycleConfigurationUpdate,
+		DeleteContext: resourceBucketLifecycleConfigurationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: resourceBucketLifecycleConfigurationCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(lifecycleConfigurationRulesPropagationTimeout),
+			Read:   schema.DefaultTimeout(lifecycleConfigurationRulesSteadyTimeout),
+			Update: schema.DefaultTimeout(lifecycleConfigurationRulesPropagationTimeout),
+			Delete: schema.DefaultTimeout(propagationTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 63),
+			},
+			"rule": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1000,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringLenBetween(1, 255),
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								s3.ExpirationStatusEnabled,
+								s3.ExpirationStatusDisabled,
+							}, false),
+						},
+						"filter": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"prefix": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"tags": {
+										Type:     schema.TypeMap,
+										Optional: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+								},
+							},
+						},
+						"expiration": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"date": {
+										Type:             schema.TypeString,
+										Optional:         true,
+										ValidateFunc:     validation.IsRFC3339Time,
+										DiffSuppressFunc: suppressEquivalentRFC3339Time,
+									},
+									"days": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										ValidateFunc: validation.IntAtLeast(1),
+									},
+									"expired_object_delete_marker": {
+										Type:     schema.TypeBool,
+										Optional: true,
+									},
+								},
+							},
+						},
+						"noncurrent_version_expiration": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"noncurrent_days": {
+										Type:         schema.TypeInt,
+										Required:     true,
+										ValidateFunc: validation.IntAtLeast(1),
+									},
+									"newer_noncurrent_versions": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										ValidateFunc: validation.IntAtLeast(1),
+									},
+								},
+							},
+						},
+						"abort_incomplete_multipart_upload": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"days_after_initiation": {
+										Type:         schema.TypeInt,
+										Required:     true,
+										ValidateFunc: validation.IntAtLeast(1),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// resourceBucketLifecycleConfigurationCreate -
+func resourceBucketLifecycleConfigurationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(*CortxClient).S3
+	bucket := d.Get("bucket").(string)
+
+	if diags := resourceBucketInternalLifecycleConfigurationPut(ctx, client, bucket, d.Get("rule").([]interface{}), d.Timeout(schema.TimeoutCreate)); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(bucket)
+	return resourceBucketLifecycleConfigurationRead(ctx, d, meta)
+}
+
+// resourceBucketLifecycleConfigurationRead - A single read, settling after writes is left to
+// resourceBucketInternalLifecycleConfigurationPut s.t. plans don't pay for it
+func resourceBucketLifecycleConfigurationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	client := meta.(*CortxClient).S3
+
+	output, err := client.GetBucketLifecycleConfigurationWithContext(ctx, &s3.GetBucketLifecycleConfigurationInput{
+		Bucket: aws.String(d.Id()),
+	})
+
+	// Configuration (or Bucket) Removed Outside of Terraform - Remove From State
+	if !d.IsNewResource() && tfawserr.ErrCodeEquals(err, ErrCodeNoSuchLifecycleConfiguration, s3.ErrCodeNoSuchBucket) {
+		log.Printf("[WARN] Lifecycle Configuration for Bucket (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return diags
+	}
+
+	if err != nil {
+		return append(diags, OperationErrorDiagnostic("GetBucketLifecycleConfiguration", d.Id(), err))
+	}
+
+	d.Set("bucket", d.Id())
+
+	if err := d.Set("rule", flattenBucketLifecycleRules(output.Rules, bucketLifecycleRulesWithoutFilter(d.Get("rule").([]interface{})))); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("[ERROR] Failed setting rule (%s):", d.Id()),
+			Detail:   fmt.Sprintf("[ERROR] %v", err),
+		})
+		return diags
+	}
+
+	return diags
+}
+
+// resourceBucketLifecycleConfigurationUpdate -
+func resourceBucketLifecycleConfigurationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(*CortxClient).S3
+
+	if d.HasChange("rule") {
+		if diags := resourceBucketInternalLifecycleConfigurationPut(ctx, client, d.Id(), d.Get("rule").([]interface{}), d.Timeout(schema.TimeoutUpdate)); diags.HasError() {
+			return diags
+		}
+	}
+
+	return resourceBucketLifecycleConfigurationRead(ctx, d, meta)
+}
+
+// resourceBucketLifecycleConfigurationDelete -
+func resourceBucketLifecycleConfigurationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	client := meta.(*CortxClient).S3
+
+	_, err := client.DeleteBucketLifecycleWithContext(ctx, &s3.DeleteBucketLifecycleInput{
+		Bucket: aws.String(d.Id()),
+	})
+
+	// Configuration (or Bucket) Already Gone - Successful "Delete"
+	if tfawserr.ErrCodeEquals(err, ErrCodeNoSuchLifecycleConfiguration, s3.ErrCodeNoSuchBucket) {
+		return diags
+	}
+
+	if err != nil {
+		return append(diags, OperationErrorDiagnostic("DeleteBucketLifecycle", d.Id(), err))
+	}
+
+	return diags
+}
+
+// resourceBucketInternalLifecycleConfigurationPut - Puts the full set of lifecycle rules and
+// waits until GetBucketLifecycleConfiguration reflects them
+func resourceBucketInternalLifecycleConfigurationPut(ctx context.Context, client *s3.S3, bucket string, l []interface{}, timeout time.Duration) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	rules := expandBucketLifecycleRules(l)
+
+	_, err := RetryWhenAWSErrCodeEqualsContext(
+		ctx,
+		timeout,
+		func() (interface{}, error) {
+			return client.PutBucketLifecycleConfigurationWithContext(ctx, &s3.PutBucketLifecycleConfigurationInput{
+				Bucket: aws.String(bucket),
+				LifecycleConfiguration: &s3.BucketLifecycleConfiguration{
+					Rules: rules,
+				},
+			})
+		},
+		s3.ErrCodeNoSuchBucket,
+	)
+
+	if err != nil {
+		return append(diags, OperationErrorDiagnostic("PutBucketLifecycleConfiguration", bucket, err))
+	}
+
+	if err := waitForLifecycleConfigurationRulesStatus(ctx, client, bucket, rules, timeout); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("[ERROR] Failed waiting on PutBucketLifecycleConfiguration (%s):", bucket),
+			Detail:   fmt.Sprintf("[ERROR] %v", err),
+		})
+		return diags
+	}
+
+	if err := waitForLifecycleConfigurationStable(ctx, client, bucket, timeout); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("[ERROR] Failed waiting on PutBucketLifecycleConfiguration (%s):", bucket),
+			Detail:   fmt.Sprintf("[ERROR] %v", err),
+		})
+		return diags
+	}
+
+	return diags
+}
+
+// waitForLifecycleConfigurationStable - NOTE From AWS Provider: the lifecycle configuration can
+// flip between the old and new rules for some time after a Put, wait until two consecutive reads
+// agree. Only needed right after a write
+func waitForLifecycleConfigurationStable(ctx context.Context, client *s3.S3, bucket string, timeout time.Duration) error {
+
+	var lastOutput *s3.GetBucketLifecycleConfigurationOutput
+
+	err := resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+
+		select {
+		case <-ctx.Done():
+			return resource.NonRetryableError(ctx.Err())
+		case <-time.After(lifecycleConfigurationExtraRetryDelay):
+		}
+
+		output, err := client.GetBucketLifecycleConfigurationWithContext(ctx, &s3.GetBucketLifecycleConfigurationInput{
+			Bucket: aws.String(bucket),
+		})
+
+		if tfawserr.ErrCodeEquals(err, ErrCodeNoSuchLifecycleConfiguration, s3.ErrCodeNoSuchBucket) {
+			return resource.RetryableError(err)
+		}
+
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		if lastOutput == nil || !reflect.DeepEqual(*lastOutput, *output) {
+			lastOutput = output
+			return resource.RetryableError(fmt.Errorf("bucket lifecycle configuration has not stabilized, trying again"))
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("waiting for S3 Bucket (%s) lifecycle configuration to stabilize: %w", bucket, err)
+	}
+
+	return nil
+}
+
+// resourceBucketLifecycleConfigurationCustomizeDiff - Rejects `expiration` blocks the server would
+// reject at apply time. Blocks w. values unknown at plan time are left to the server
+func resourceBucketLifecycleConfigurationCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+
+	for i, rule := range d.Get("rule").([]interface{}) {
+		tfMap, ok := rule.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		v, ok := tfMap["expiration"].([]interface{})
+
+		if !ok || len(v) == 0 {
+			continue
+		}
+
+		known := true
+		for _, k := range []string{"date", "days", "expired_object_delete_marker"} {
+			known = known && d.NewValueKnown(fmt.Sprintf("rule.%d.expiration.0.%s", i, k))
+		}
+
+		if !known {
+			continue
+		}
+
+		m, _ := v[0].(map[string]interface{})
+
+		if err := checkBucketLifecycleExpiration(m); err != nil {
+			return fmt.Errorf("rule %q: %w", tfMap["id"].(string), err)
+		}
+	}
+
+	return nil
+}
+
+// checkBucketLifecycleExpiration - An expiration sets exactly one of `days` or `date`, or only
+// `expired_object_delete_marker`
+func checkBucketLifecycleExpiration(m map[string]interface{}) error {
+
+	date, _ := m["date"].(string)
+	days, _ := m["days"].(int)
+	marker, _ := m["expired_object_delete_marker"].(bool)
+
+	switch {
+	case date != "" && days > 0:
+		return fmt.Errorf("expiration: only one of days or date can be set")
+	case (date != "" || days > 0) && marker:
+		return fmt.Errorf("expiration: expired_object_delete_marker can't be combined with days or date")
+	case date == "" && days == 0 && !marker:
+		return fmt.Errorf("expiration: one of days or date must be set")
+	}
+
+	return nil
+}
+
+// suppressEquivalentRFC3339Time - Suppress diffs between timestamps that refer to the same
+// instant (e.g. `2022-07-01T00:00:00Z` and `2022-07-01T00:00:00+00:00`)
+func suppressEquivalentRFC3339Time(k, old, new string, d *schema.ResourceData) bool {
+
+	oldTime, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+
+	newTime, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+
+	return oldTime.Equal(newTime)
+}
+
+// expandBucketLifecycleRules - Converts `rule` blocks to CORTX API lifecycle rules
+func expandBucketLifecycleRules(l []interface{}) []*s3.LifecycleRule {
+
+	var rules []*s3.LifecycleRule
+
+	for _, tfMapRaw := range l {
+		tfMap, ok := tfMapRaw.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		rule := &s3.LifecycleRule{
+			ID:     aws.String(tfMap["id"].(string)),
+			Status: aws.String(tfMap["status"].(string)),
+		}
+
+		if v, ok := tfMap["filter"].([]interface{}); ok {
+			rule.Filter = expandBucketLifecycleRuleFilter(v)
+		}
+
+		if v, ok := tfMap["expiration"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			rule.Expiration = expandBucketLifecycleExpiration(v[0].(map[string]interface{}))
+		}
+
+		if v, ok := tfMap["noncurrent_version_expiration"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			m := v[0].(map[string]interface{})
+
+			rule.NoncurrentVersionExpiration = &s3.NoncurrentVersionExpiration{
+				NoncurrentDays: aws.Int64(int64(m["noncurrent_days"].(int))),
+			}
+
+			if n := m["newer_noncurrent_versions"].(int); n > 0 {
+				rule.NoncurrentVersionExpiration.NewerNoncurrentVersions = aws.Int64(int64(n))
+			}
+		}
+
+		if v, ok := tfMap["abort_incomplete_multipart_upload"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			m := v[0].(map[string]interface{})
+
+			rule.AbortIncompleteMultipartUpload = &s3.AbortIncompleteMultipartUpload{
+				DaysAfterInitiation: aws.Int64(int64(m["days_after_initiation"].(int))),
+			}
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
+
+// expandBucketLifecycleRuleFilter - A rule always carries a filter, an omitted `filter` block
+// applies the rule to the whole bucket. Tags are sorted by key s.t. repeated applies send
+// an identical request
+func expandBucketLifecycleRuleFilter(l []interface{}) *s3.LifecycleRuleFilter {
+
+	var (
+		prefix string
+		tags   []*s3.Tag
+	)
+
+	if len(l) > 0 && l[0] != nil {
+		tfMap := l[0].(map[string]interface{})
+		prefix, _ = tfMap["prefix"].(string)
+
+		if v, ok := tfMap["tags"].(map[string]interface{}); ok {
+			for key, value := range v {
+				tags = append(tags, &s3.Tag{Key: aws.String(key), Value: aws.String(value.(string))})
+			}
+		}
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		return aws.StringValue(tags[i].Key) < aws.StringValue(tags[j].Key)
+	})
+
+	switch {
+	case len(tags) == 0:
+		return &s3.LifecycleRuleFilter{Prefix: aws.String(prefix)}
+	case len(tags) == 1 && prefix == "":
+		return &s3.LifecycleRuleFilter{Tag: tags[0]}
+	}
+
+	and := &s3.LifecycleRuleAndOperator{Tags: tags}
+	if prefix != "" {
+		and.Prefix = aws.String(prefix)
+	}
+
+	return &s3.LifecycleRuleFilter{And: and}
+}
+
+// expandBucketLifecycleExpiration -
+func expandBucketLifecycleExpiration(m map[string]interface{}) *s3.LifecycleExpiration {
+
+	expiration := &s3.LifecycleExpiration{}
+
+	if v, ok := m["date"].(string); ok && v != "" {
+		t, _ := time.Parse(time.RFC3339, v) // Validated at plan time
+		expiration.Date = aws.Time(t)
+	}
+
+	if v, ok := m["days"].(int); ok && v > 0 {
+		expiration.Days = aws.Int64(int64(v))
+	}
+
+	if v, ok := m["expired_object_delete_marker"].(bool); ok && v {
+		expiration.ExpiredObjectDeleteMarker = aws.Bool(v)
+	}
+
+	return expiration
+}
+
+// bucketLifecycleRulesWithoutFilter - IDs of the rules (in config or state) w/o a `filter` block.
+// The server can't tell an omitted filter from `filter { prefix = "" }`, both apply to the whole
+// bucket
+func bucketLifecycleRulesWithoutFilter(l []interface{}) map[string]bool {
+
+	ids := map[string]bool{}
+
+	for _, tfMapRaw := range l {
+		tfMap, ok := tfMapRaw.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		if v, ok := tfMap["filter"].([]interface{}); !ok || len(v) == 0 {
+			ids[tfMap["id"].(string)] = true
+		}
+	}
+
+	return ids
+}
+
+// flattenBucketLifecycleRules - Converts CORTX API lifecycle rules to `rule` blocks, a whole-bucket
+// filter on a rule in `withoutFilter` flattens to no `filter` block
+func flattenBucketLifecycleRules(rules []*s3.LifecycleRule, withoutFilter map[string]bool) []interface{} {
+
+	var results []interface{}
+
+	for _, rule := range rules {
+		if rule == nil {
+			continue
+		}
+
+		m := map[string]interface{}{
+			"id":     aws.StringValue(rule.ID),
+			"status": aws.StringValue(rule.Status),
+			"filter": flattenBucketLifecycleRuleFilter(rule.Filter),
+		}
+
+		// Rules written w. the legacy rule-level Prefix (e.g. by other S3 clients)
+		if rule.Filter == nil && aws.StringValue(rule.Prefix) != "" {
+			m["filter"] = flattenBucketLifecycleRuleFilter(&s3.LifecycleRuleFilter{Prefix: rule.Prefix})
+		}
+
+		if withoutFilter[aws.StringValue(rule.ID)] && isWholeBucketLifecycleRuleFilter(m["filter"].([]interface{})) {
+			m["filter"] = nil
+		}
+
+		if v := rule.Expiration; v != nil {
+			expiration := map[string]interface{}{
+				"days":                         int(aws.Int64Value(v.Days)),
+				"expired_object_delete_marker": aws.BoolValue(v.ExpiredObjectDeleteMarker),
+			}
+
+			if v.Date != nil {
+				expiration["date"] = v.Date.UTC().Format(time.RFC3339)
+			}
+
+			m["expiration"] = []interface{}{expiration}
+		}
+
+		if v := rule.NoncurrentVersionExpiration; v != nil {
+			m["noncurrent_version_expiration"] = []interface{}{
+				map[string]interface{}{
+					"noncurrent_days":           int(aws.Int64Value(v.NoncurrentDays)),
+					"newer_noncurrent_versions": int(aws.Int64Value(v.NewerNoncurrentVersions)),
+				},
+			}
+		}
+
+		if v := rule.AbortIncompleteMultipartUpload; v != nil {
+			m["abort_incomplete_multipart_upload"] = []interface{}{
+				map[string]interface{}{
+					"days_after_initiation": int(aws.Int64Value(v.DaysAfterInitiation)),
+				},
+			}
+		}
+
+		results = append(results, m)
+	}
+
+	return results
+}
+
+// flattenBucketLifecycleRuleFilter - A whole-bucket filter (empty prefix, no tags) flattens to a
+// block w. an empty prefix, matching `filter { prefix = "" }`
+func flattenBucketLifecycleRuleFilter(filter *s3.LifecycleRuleFilter) []interface{} {
+
+	if filter == nil {
+		return nil
+	}
+
+	var (
+		prefix string
+		tags   = map[string]interface{}{}
+	)
+
+	switch {
+	case filter.And != nil:
+		prefix = aws.StringValue(filter.And.Prefix)
+		for _, tag := range filter.And.Tags {
+			tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+		}
+	case filter.Tag != nil:
+		tags[aws.StringValue(filter.Tag.Key)] = aws.StringValue(filter.Tag.Value)
+	default:
+		prefix = aws.StringValue(filter.Prefix)
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"prefix": prefix,
+			"tags":   tags,
+		},
+	}
+}
+
+// isWholeBucketLifecycleRuleFilter - Reports whether a flattened `filter` matches every object
+func isWholeBucketLifecycleRuleFilter(l []interface{}) bool {
+
+	if len(l) == 0 || l[0] == nil {
+		return true
+	}
+
+	tfMap := l[0].(map[string]interface{})
+	tags, _ := tfMap["tags"].(map[string]interface{})
+
+	return tfMap["prefix"].(string) == "" && len(tags) == 0
+}
@@ -0,0 +1,94 @@
+package cortx
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBucketLifecycleRuleFilterRoundTrip(t *testing.T) {
+
+	cases := map[string][]interface{}{
+		"empty prefix": {
+			map[string]interface{}{"prefix": "", "tags": map[string]interface{}{}},
+		},
+		"prefix only": {
+			map[string]interface{}{"prefix": "logs/", "tags": map[string]interface{}{}},
+		},
+		"single tag": {
+			map[string]interface{}{"prefix": "", "tags": map[string]interface{}{"retention": "short"}},
+		},
+		"prefix and tags": {
+			map[string]interface{}{"prefix": "scratch/", "tags": map[string]interface{}{"a": "1", "b": "2"}},
+		},
+	}
+
+	for name, filter := range cases {
+		got := flattenBucketLifecycleRuleFilter(expandBucketLifecycleRuleFilter(filter))
+		if !reflect.DeepEqual(got, filter) {
+			t.Errorf("%s: expected %#v, got %#v", name, filter, got)
+		}
+	}
+}
+
+func TestFlattenBucketLifecycleRulesWholeBucketFilter(t *testing.T) {
+
+	config := []interface{}{
+		map[string]interface{}{"id": "omitted", "filter": []interface{}{}},
+		map[string]interface{}{"id": "empty-prefix", "filter": []interface{}{
+			map[string]interface{}{"prefix": "", "tags": map[string]interface{}{}},
+		}},
+		map[string]interface{}{"id": "prefix", "filter": []interface{}{
+			map[string]interface{}{"prefix": "logs/", "tags": map[string]interface{}{}},
+		}},
+	}
+
+	rules := flattenBucketLifecycleRules(expandBucketLifecycleRules([]interface{}{
+		map[string]interface{}{"id": "omitted", "status": "Enabled", "filter": []interface{}{}},
+		map[string]interface{}{"id": "empty-prefix", "status": "Enabled", "filter": config[1].(map[string]interface{})["filter"]},
+		map[string]interface{}{"id": "prefix", "status": "Enabled", "filter": config[2].(map[string]interface{})["filter"]},
+	}), bucketLifecycleRulesWithoutFilter(config))
+
+	for i, rule := range rules {
+		got, _ := rule.(map[string]interface{})["filter"].([]interface{})
+		expected := config[i].(map[string]interface{})["filter"].([]interface{})
+
+		if len(got) != len(expected) || (len(got) > 0 && !reflect.DeepEqual(got, expected)) {
+			t.Errorf("%s: expected filter %#v, got %#v", config[i].(map[string]interface{})["id"], expected, got)
+		}
+	}
+}
+
+func TestSuppressEquivalentRFC3339Time(t *testing.T) {
+
+	if !suppressEquivalentRFC3339Time("date", "2022-07-01T00:00:00Z", "2022-07-01T00:00:00+00:00", nil) {
+		t.Error("expected equivalent timestamps to be suppressed")
+	}
+
+	if suppressEquivalentRFC3339Time("date", "2022-07-01T00:00:00Z", "2022-07-02T00:00:00Z", nil) {
+		t.Error("expected different timestamps not to be suppressed")
+	}
+}
+
+func TestCheckBucketLifecycleExpiration(t *testing.T) {
+
+	cases := []struct {
+		name      string
+		m         map[string]interface{}
+		expectErr bool
+	}{
+		{"days", map[string]interface{}{"days": 30}, false},
+		{"date", map[string]interface{}{"date": "2022-07-01T00:00:00Z"}, false},
+		{"delete marker", map[string]interface{}{"expired_object_delete_marker": true}, false},
+		{"empty", map[string]interface{}{"date": "", "days": 0, "expired_object_delete_marker": false}, true},
+		{"nil block", nil, true},
+		{"days and date", map[string]interface{}{"days": 30, "date": "2022-07-01T00:00:00Z"}, true},
+		{"days and delete marker", map[string]interface{}{"days": 30, "expired_object_delete_marker": true}, true},
+	}
+
+	for _, tc := range cases {
+		err := checkBucketLifecycleExpiration(tc.m)
+		if tc.expectErr != (err != nil) {
+			t.Errorf("%s: expected error: %t, got: %v", tc.name, tc.expectErr, err)
+		}
+	}
+}
@@ -0,0 +1,310 @@
+package cortx
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/aws-sdk-go-base/v2/awsv1shim/v2/tfawserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+)
+
+// resourceBucketObjectLockConfiguration
+//
+// Standalone resource for managing a bucket's default retention, takes the place of the
+// `object_lock_configuration` block that was removed from `resourceBucketUpdate`. The bucket
+// must have been created w. `object_lock_enabled = true`, object lock can't be turned on (or
+// off) after creation. Modeled on the AWS provider's `aws_s3_bucket_object_lock_configuration`
+//
+// See: https://github.com/hashicorp/terraform-provider-aws/blob/main/internal/service/s3/bucket_object_lock_configuration.go
+func resourceBucketObjectLockConfiguration() *schema.Resource {
+
+	return &schema.Resource{
+		CreateContext: resourceBucketObjectLockConfigurationCreate,
+		ReadContext:   resourceBucketObjectLockConfigurationRead,
+		UpdateContext: resourceBucketObjectLockConfigurationUpdate,
+		DeleteContext: resourceBucketObjectLockConfigurationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(propagationTimeout),
+			Read:   schema.DefaultTimeout(propagationTimeout),
+			Update: schema.DefaultTimeout(propagationTimeout),
+			Delete: schema.DefaultTimeout(propagationTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 63),
+			},
+			"object_lock_enabled": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      s3.ObjectLockEnabledEnabled,
+				ValidateFunc: validation.StringInSlice(s3.ObjectLockEnabled_Values(), false),
+			},
+			"rule": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"default_retention": {
+							Type:     schema.TypeList,
+							Required: true,
+							MinItems: 1,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"mode": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringInSlice(s3.ObjectLockRetentionMode_Values(), false),
+									},
+									"days": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										ValidateFunc: validation.IntAtLeast(1),
+										ExactlyOneOf: []string{"rule.0.default_retention.0.days", "rule.0.default_retention.0.years"},
+									},
+									"years": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										ValidateFunc: validation.IntAtLeast(1),
+										ExactlyOneOf: []string{"rule.0.default_retention.0.days", "rule.0.default_retention.0.years"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// resourceBucketObjectLockConfigurationCreate -
+func resourceBucketObjectLockConfigurationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	client := meta.(*CortxClient).S3
+	bucket := d.Get("bucket").(string)
+
+	// Fail early (and clearly) on buckets that weren't created w. object lock enabled, otherwise
+	// the Put fails w. a generic InvalidBucketState
+	output, err := client.GetObjectLockConfigurationWithContext(ctx, &s3.GetObjectLockConfigurationInput{
+		Bucket: aws.String(bucket),
+	})
+
+	if tfawserr.ErrCodeEquals(err, ErrCodeObjectLockConfigurationNotFound) ||
+		(err == nil && aws.StringValue(objectLockEnabledStatus(output)) != s3.ObjectLockEnabledEnabled) {
+		return append(diags, objectLockNotEnabledDiagnostic(bucket))
+	}
+
+	if err != nil {
+		return append(diags, OperationErrorDiagnostic("GetObjectLockConfiguration", bucket, err))
+	}
+
+	if diags := resourceBucketInternalObjectLockConfigurationPut(ctx, client, bucket, d); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(bucket)
+	return resourceBucketObjectLockConfigurationRead(ctx, d, meta)
+}
+
+// resourceBucketObjectLockConfigurationRead -
+func resourceBucketObjectLockConfigurationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	client := meta.(*CortxClient).S3
+
+	output, err := client.GetObjectLockConfigurationWithContext(ctx, &s3.GetObjectLockConfigurationInput{
+		Bucket: aws.String(d.Id()),
+	})
+
+	// Bucket Removed Outside of Terraform - Remove From State
+	if !d.IsNewResource() && tfawserr.ErrCodeEquals(err, s3.ErrCodeNoSuchBucket, ErrCodeObjectLockConfigurationNotFound) {
+		log.Printf("[WARN] Object Lock Configuration for Bucket (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return diags
+	}
+
+	if err != nil {
+		return append(diags, OperationErrorDiagnostic("GetObjectLockConfiguration", d.Id(), err))
+	}
+
+	d.Set("bucket", d.Id())
+	d.Set("object_lock_enabled", objectLockEnabledStatus(output))
+
+	if err := d.Set("rule", flattenBucketObjectLockRule(output.ObjectLockConfiguration)); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("[ERROR] Failed setting rule (%s):", d.Id()),
+			Detail:   fmt.Sprintf("[ERROR] %v", err),
+		})
+		return diags
+	}
+
+	return diags
+}
+
+// resourceBucketObjectLockConfigurationUpdate -
+func resourceBucketObjectLockConfigurationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	client := meta.(*CortxClient).S3
+
+	if d.HasChange("rule") {
+		if diags := resourceBucketInternalObjectLockConfigurationPut(ctx, client, d.Id(), d); diags.HasError() {
+			return diags
+		}
+	}
+
+	return resourceBucketObjectLockConfigurationRead(ctx, d, meta)
+}
+
+// resourceBucketObjectLockConfigurationDelete - Object lock can't be disabled once enabled, the
+// best "delete" available is dropping the default retention rule
+func resourceBucketObjectLockConfigurationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	client := meta.(*CortxClient).S3
+
+	_, err := client.PutObjectLockConfigurationWithContext(ctx, &s3.PutObjectLockConfigurationInput{
+		Bucket:                  aws.String(d.Id()),
+		ObjectLockConfiguration: expandBucketObjectLockConfiguration(s3.ObjectLockEnabledEnabled, nil),
+	})
+
+	// Bucket Already Gone - Successful "Delete"
+	if tfawserr.ErrCodeEquals(err, s3.ErrCodeNoSuchBucket, ErrCodeObjectLockConfigurationNotFound) {
+		return diags
+	}
+
+	if err != nil {
+		return append(diags, OperationErrorDiagnostic("PutObjectLockConfiguration", d.Id(), err))
+	}
+
+	return diags
+}
+
+// resourceBucketInternalObjectLockConfigurationPut -
+func resourceBucketInternalObjectLockConfigurationPut(ctx context.Context, client *s3.S3, bucket string, d *schema.ResourceData) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	_, err := RetryWhenAWSErrCodeEqualsContext(
+		ctx,
+		applyTimeout(d),
+		func() (interface{}, error) {
+			return client.PutObjectLockConfigurationWithContext(ctx, &s3.PutObjectLockConfigurationInput{
+				Bucket:                  aws.String(bucket),
+				ObjectLockConfiguration: expandBucketObjectLockConfiguration(d.Get("object_lock_enabled").(string), d.Get("rule").([]interface{})),
+			})
+		},
+		s3.ErrCodeNoSuchBucket,
+	)
+
+	if tfawserr.ErrCodeEquals(err, ErrCodeInvalidBucketState) {
+		return append(diags, objectLockNotEnabledDiagnostic(bucket))
+	}
+
+	if err != nil {
+		return append(diags, OperationErrorDiagnostic("PutObjectLockConfiguration", bucket, err))
+	}
+
+	return diags
+}
+
+// objectLockNotEnabledDiagnostic -
+func objectLockNotEnabledDiagnostic(bucket string) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("[ERROR] Object lock not enabled on Bucket (%s):", bucket),
+		Detail: fmt.Sprintf(
+			"[ERROR] Bucket (%s) was not created w. object lock enabled, object lock can only be enabled at "+
+				"creation (`object_lock_enabled = true` on cortx_bucket), which replaces the bucket", bucket,
+		),
+	}
+}
+
+// objectLockEnabledStatus -
+func objectLockEnabledStatus(output *s3.GetObjectLockConfigurationOutput) *string {
+	if output == nil || output.ObjectLockConfiguration == nil {
+		return nil
+	}
+	return output.ObjectLockConfiguration.ObjectLockEnabled
+}
+
+// expandBucketObjectLockConfiguration - An empty `rule` leaves out the default retention
+func expandBucketObjectLockConfiguration(enabled string, rule []interface{}) *s3.ObjectLockConfiguration {
+	return &s3.ObjectLockConfiguration{
+		ObjectLockEnabled: aws.String(enabled),
+		Rule:              expandBucketObjectLockRule(rule),
+	}
+}
+
+// expandBucketObjectLockRule - Converts the `rule` block to a CORTX API object lock rule
+func expandBucketObjectLockRule(l []interface{}) *s3.ObjectLockRule {
+
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	tfMap, ok := l[0].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	retentions, ok := tfMap["default_retention"].([]interface{})
+	if !ok || len(retentions) == 0 || retentions[0] == nil {
+		return nil
+	}
+
+	m := retentions[0].(map[string]interface{})
+
+	retention := &s3.DefaultRetention{
+		Mode: aws.String(m["mode"].(string)),
+	}
+
+	if v, ok := m["days"].(int); ok && v > 0 {
+		retention.Days = aws.Int64(int64(v))
+	}
+
+	if v, ok := m["years"].(int); ok && v > 0 {
+		retention.Years = aws.Int64(int64(v))
+	}
+
+	return &s3.ObjectLockRule{DefaultRetention: retention}
+}
+
+// flattenBucketObjectLockRule - Converts a CORTX API object lock configuration to a `rule` block
+func flattenBucketObjectLockRule(config *s3.ObjectLockConfiguration) []interface{} {
+
+	if config == nil || config.Rule == nil || config.Rule.DefaultRetention == nil {
+		return nil
+	}
+
+	retention := config.Rule.DefaultRetention
+
+	return []interface{}{
+		map[string]interface{}{
+			"default_retention": []interface{}{
+				map[string]interface{}{
+					"mode":  aws.StringValue(retention.Mode),
+					"days":  int(aws.Int64Value(retention.Days)),
+					"years": int(aws.Int64Value(retention.Years)),
+				},
+			},
+		},
+	}
+}
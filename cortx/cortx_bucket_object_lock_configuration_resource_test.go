@@ -0,0 +1,81 @@
+package cortx
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"reflect"
+	"testing"
+)
+
+func TestBucketObjectLockRuleRoundTrip(t *testing.T) {
+
+	cases := map[string][]interface{}{
+		"days": {
+			map[string]interface{}{
+				"default_retention": []interface{}{
+					map[string]interface{}{"mode": s3.ObjectLockRetentionModeGovernance, "days": 30, "years": 0},
+				},
+			},
+		},
+		"years": {
+			map[string]interface{}{
+				"default_retention": []interface{}{
+					map[string]interface{}{"mode": s3.ObjectLockRetentionModeCompliance, "days": 0, "years": 7},
+				},
+			},
+		},
+	}
+
+	for name, rule := range cases {
+		config := expandBucketObjectLockConfiguration(s3.ObjectLockEnabledEnabled, rule)
+
+		if got := flattenBucketObjectLockRule(config); !reflect.DeepEqual(got, rule) {
+			t.Errorf("%s: expected %#v, got %#v", name, rule, got)
+		}
+	}
+}
+
+func TestExpandBucketObjectLockRuleDaysOrYears(t *testing.T) {
+
+	days := expandBucketObjectLockRule([]interface{}{
+		map[string]interface{}{
+			"default_retention": []interface{}{
+				map[string]interface{}{"mode": s3.ObjectLockRetentionModeGovernance, "days": 30, "years": 0},
+			},
+		},
+	})
+
+	if aws.Int64Value(days.DefaultRetention.Days) != 30 || days.DefaultRetention.Years != nil {
+		t.Errorf("expected only days to be set, got %v", days.DefaultRetention)
+	}
+
+	years := expandBucketObjectLockRule([]interface{}{
+		map[string]interface{}{
+			"default_retention": []interface{}{
+				map[string]interface{}{"mode": s3.ObjectLockRetentionModeCompliance, "days": 0, "years": 1},
+			},
+		},
+	})
+
+	if aws.Int64Value(years.DefaultRetention.Years) != 1 || years.DefaultRetention.Days != nil {
+		t.Errorf("expected only years to be set, got %v", years.DefaultRetention)
+	}
+}
+
+func TestExpandBucketObjectLockConfigurationDropsRule(t *testing.T) {
+
+	// Delete puts the configuration w/o a rule, object lock itself stays enabled
+	config := expandBucketObjectLockConfiguration(s3.ObjectLockEnabledEnabled, nil)
+
+	if config.Rule != nil {
+		t.Errorf("expected no rule, got %v", config.Rule)
+	}
+
+	if aws.StringValue(config.ObjectLockEnabled) != s3.ObjectLockEnabledEnabled {
+		t.Errorf("expected object lock to stay enabled, got %v", config.ObjectLockEnabled)
+	}
+
+	if got := flattenBucketObjectLockRule(config); got != nil {
+		t.Errorf("expected no rule block, got %#v", got)
+	}
+}
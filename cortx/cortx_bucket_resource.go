@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/hashicorp/aws-sdk-go-base/v2/awsv1shim/v2/tfawserr"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -13,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"log"
 	"net/http"
+	"time"
 )
 
 //
@@ -23,18 +25,32 @@ func resourceBucket() *schema.Resource {
 		ReadContext:   resourceBucketRead,
 		UpdateContext: resourceBucketUpdate,
 		DeleteContext: resourceBucketDelete,
+		CustomizeDiff: resourceBucketCustomizeDiff,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: resourceBucketImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(bucketCreatedTimeout),
+			Read:   schema.DefaultTimeout(bucketCreatedTimeout),
+			Update: schema.DefaultTimeout(bucketUpdateTimeout),
+			Delete: schema.DefaultTimeout(bucketDeleteTimeout),
 		},
 		Schema: map[string]*schema.Schema{
 			"bucket": {
-				Type:         schema.TypeString,
-				Optional:     true, // False (?)
-				Computed:     true,
-				ForceNew:     true,
-				ValidateFunc: validation.StringLenBetween(0, 63),
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"bucket_prefix"},
+				ValidateFunc:  validateBucketName,
+			},
+			"bucket_prefix": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"bucket"},
+				ValidateFunc:  validateBucketPrefix,
 			},
-			// Remove for Clarity (`bucket_prefix`) - Make Name Required
 			"bucket_domain_name": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -48,11 +64,84 @@ func resourceBucket() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			"region": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
 			"force_destroy": {
 				Type:     schema.TypeBool,
 				Optional: true,
 				Default:  false,
 			},
+			"backup_on_destroy": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"bucket": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateBucketName,
+							ExactlyOneOf: []string{"backup_on_destroy.0.bucket", "backup_on_destroy.0.local_path"},
+						},
+						"local_path": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+							ExactlyOneOf: []string{"backup_on_destroy.0.bucket", "backup_on_destroy.0.local_path"},
+						},
+						"prefix": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"deletion_protection": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"force_destroy_max_objects": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"force_destroy_object_lock_handling": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  ObjectLockHandlingRespect,
+				ValidateFunc: validation.StringInSlice([]string{
+					ObjectLockHandlingRespect,
+					ObjectLockHandlingBypassGovernance,
+					ObjectLockHandlingRemoveLegalHolds,
+				}, false),
+			},
+			"versioning": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"mfa_delete": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
 			"object_lock_enabled": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -75,12 +164,10 @@ func resourceBucketCreate(ctx context.Context, d *schema.ResourceData, meta inte
 		bucket string
 	)
 
-	client := meta.(*s3.S3)
+	client := meta.(*CortxClient).S3
 
-	// Get The ID of the Bucket - One of a Few Possible Methods
-	if v, ok := d.GetOk("bucket"); ok {
-		bucket = v.(string)
-	}
+	// Get The ID of the Bucket - Configured Name, or Generated From `bucket_prefix`
+	bucket = bucketName(d.Get("bucket").(string), d.Get("bucket_prefix").(string))
 
 	// Init Create Request
 	createRequest := &s3.CreateBucketInput{
@@ -88,11 +175,16 @@ func resourceBucketCreate(ctx context.Context, d *schema.ResourceData, meta inte
 		ObjectLockEnabledForBucket: aws.Bool(d.Get("object_lock_enabled").(bool)),
 	}
 
+	// Send the Location Constraint
+	if v, ok := d.GetOk("region"); ok {
+		createRequest.CreateBucketConfiguration = expandBucketCreateConfiguration(v.(string))
+	}
+
 	//
 	// Try to Create a Bucket w. Retry
 	//
-	err := resource.Retry(bucketCreatedTimeout, func() *resource.RetryError {
-		_, err := client.CreateBucket(createRequest)
+	err := resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+		_, err := client.CreateBucketWithContext(ctx, createRequest)
 		if awsErr, ok := err.(awserr.Error); ok {
 			if awsErr.Code() == ErrCodeOperationAborted {
 				return resource.RetryableError(
@@ -110,7 +202,7 @@ func resourceBucketCreate(ctx context.Context, d *schema.ResourceData, meta inte
 
 	// Try once more after the TimeOut
 	if TimedOut(err) {
-		_, err = client.CreateBucket(createRequest)
+		_, err = client.CreateBucketWithContext(ctx, createRequest)
 
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Warning,
@@ -129,6 +221,16 @@ func resourceBucketCreate(ctx context.Context, d *schema.ResourceData, meta inte
 		return diags
 	}
 
+	// Follow-up config calls may land on nodes that haven't seen the bucket yet
+	if err := waitForBucketCreated(ctx, client, bucket, d.Timeout(schema.TimeoutCreate)); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("[ERROR] Failed waiting on CreateBucket (%s):", bucket),
+			Detail:   fmt.Sprintf("[ERROR] %v", err),
+		})
+		return diags
+	}
+
 	d.SetId(bucket)
 	return resourceBucketUpdate(ctx, d, meta)
 }
@@ -138,15 +240,15 @@ func resourceBucketRead(ctx context.Context, d *schema.ResourceData, meta interf
 
 	var diags diag.Diagnostics
 
-	client := meta.(*s3.S3)
+	client := meta.(*CortxClient).S3
 
 	headBucketInp := &s3.HeadBucketInput{
 		Bucket: aws.String(d.Id()),
 	}
 
-	err := resource.Retry(bucketCreatedTimeout, func() *resource.RetryError {
+	err := resource.Retry(d.Timeout(schema.TimeoutRead), func() *resource.RetryError {
 
-		_, err := client.HeadBucket(headBucketInp)
+		_, err := client.HeadBucketWithContext(ctx, headBucketInp)
 
 		if d.IsNewResource() && tfawserr.ErrStatusCodeEquals(err, http.StatusNotFound) {
 			return resource.RetryableError(err)
@@ -164,7 +266,7 @@ func resourceBucketRead(ctx context.Context, d *schema.ResourceData, meta interf
 	})
 
 	if TimedOut(err) {
-		_, err = client.HeadBucket(headBucketInp)
+		_, err = client.HeadBucketWithContext(ctx, headBucketInp)
 	}
 
 	// Failed to Get Bucket - Return Diagnostics
@@ -177,11 +279,285 @@ func resourceBucketRead(ctx context.Context, d *schema.ResourceData, meta interf
 		return diags
 	}
 
-	// TODO: Set Parameters Back to the Resource Data //
+	// Set Parameters Back to the Resource Data - Hydrates Imported Buckets
+	d.Set("bucket", d.Id())
+
+	// Region - Servers w/o GetBucketLocation Keep the Configured Region
+	region, err := findBucketRegion(ctx, client, d.Id())
+
+	if err != nil && !NotSupportedByServer(err) {
+		return append(diags, OperationErrorDiagnostic("GetBucketLocation", d.Id(), err))
+	}
+
+	if err == nil {
+		d.Set("region", bucketRegion(d.Get("region").(string), region))
+	}
+
+	d.Set("arn", bucketARN(d.Id()))
+
+	if diags := resourceBucketInternalVersioningRead(ctx, client, d); diags.HasError() {
+		return diags
+	}
+
+	if diags := resourceBucketInternalTagsRead(ctx, client, d); diags.HasError() {
+		return diags
+	}
+
+	if diags := resourceBucketInternalObjectLockRead(ctx, client, d); diags.HasError() {
+		return diags
+	}
+
+	return diags
+}
+
+// resourceBucketImport - Accepts a bucket name or ARN, and refuses buckets that don't exist or
+// aren't owned by the caller (HeadBucket also succeeds on other accounts' buckets shared by policy)
+func resourceBucketImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+
+	client := meta.(*CortxClient).S3
+
+	bucket, err := bucketNameFromImportID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = client.HeadBucketWithContext(ctx, &s3.HeadBucketInput{
+		Bucket: aws.String(bucket),
+	})
+
+	if tfawserr.ErrCodeEquals(err, s3.ErrCodeNoSuchBucket) || tfawserr.ErrStatusCodeEquals(err, http.StatusNotFound) {
+		return nil, fmt.Errorf("importing S3 Bucket (%s): bucket not found", bucket)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("importing S3 Bucket (%s): %w", bucket, err)
+	}
+
+	output, err := client.ListBucketsWithContext(ctx, &s3.ListBucketsInput{})
+	if err != nil {
+		return nil, fmt.Errorf("importing S3 Bucket (%s): listing owned buckets: %w", bucket, err)
+	}
+
+	owned := false
+	for _, b := range output.Buckets {
+		if aws.StringValue(b.Name) == bucket {
+			owned = true
+			break
+		}
+	}
+
+	if !owned {
+		return nil, fmt.Errorf("importing S3 Bucket (%s): bucket is accessible but not owned by the caller", bucket)
+	}
+
+	d.SetId(bucket)
+	d.Set("bucket", bucket)
+
+	// Provider-side settings can't be read from the server, import them w. their schema defaults
+	// s.t. the first plan after an import shows no diff for them
+	d.Set("force_destroy", false)
+	d.Set("deletion_protection", false)
+	d.Set("force_destroy_object_lock_handling", ObjectLockHandlingRespect)
+	d.Set("force_destroy_max_objects", 0)
+
+	// Importers can't return warnings, the check is logged once here rather than on every refresh.
+	// Versioning, tags, etc. are hydrated by the Read that follows the import
+	warnImportedBucketNotEmpty(ctx, client, bucket)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// warnImportedBucketNotEmpty - Warns that an imported, non-empty bucket can't be destroyed w/o
+// force_destroy. Failing to check is logged, never fatal for an import
+func warnImportedBucketNotEmpty(ctx context.Context, client *s3.S3, bucket string) {
+
+	usage, err := measureBucketUsage(ctx, client, bucket, "", 1)
+
+	if err != nil {
+		log.Printf("[WARN] Unable to check whether imported Bucket (%s) is empty: %v", bucket, err)
+		return
+	}
+
+	if usage.Versions == 0 && usage.DeleteMarkers == 0 {
+		return
+	}
+
+	log.Printf(
+		"[WARN] Imported Bucket (%s) is not empty: force_destroy defaults to false, destroying (or replacing) "+
+			"the bucket will fail while it holds objects. Set force_destroy = true to allow deleting its contents", bucket,
+	)
+}
+
+// resourceBucketInternalVersioningRead -
+func resourceBucketInternalVersioningRead(ctx context.Context, client *s3.S3, d *schema.ResourceData) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	output, err := client.GetBucketVersioningWithContext(ctx, &s3.GetBucketVersioningInput{
+		Bucket: aws.String(d.Id()),
+	})
+
+	if NotSupportedByServer(err) {
+		log.Printf("[WARN] Unable to read versioning of Bucket (%s): %v", d.Id(), err)
+		return diags
+	}
+
+	if err != nil {
+		return append(diags, OperationErrorDiagnostic("GetBucketVersioning", d.Id(), err))
+	}
+
+	if err := d.Set("versioning", flattenVersioning(output)); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("[ERROR] Failed setting versioning (%s):", d.Id()),
+			Detail:   fmt.Sprintf("[ERROR] %v", err),
+		})
+		return diags
+	}
+
+	return diags
+}
+
+// resourceBucketInternalTagsRead -
+func resourceBucketInternalTagsRead(ctx context.Context, client *s3.S3, d *schema.ResourceData) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	tags, err := findBucketTags(ctx, client, d.Id())
+
+	if NotSupportedByServer(err) {
+		log.Printf("[WARN] Unable to read tags of Bucket (%s): %v", d.Id(), err)
+		return diags
+	}
+
+	if err != nil {
+		return append(diags, OperationErrorDiagnostic("GetBucketTagging", d.Id(), err))
+	}
+
+	d.Set("tags", PointersMapToStringList(tags))
+	d.Set("tags_all", PointersMapToStringList(tags))
+
+	return diags
+}
+
+// findBucketTags - A bucket w/o tags has no tag set (NoSuchTagSet), reported as no tags
+func findBucketTags(ctx context.Context, client *s3.S3, bucket string) (map[string]*string, error) {
+
+	tags := map[string]*string{}
+
+	output, err := client.GetBucketTaggingWithContext(ctx, &s3.GetBucketTaggingInput{
+		Bucket: aws.String(bucket),
+	})
+
+	if tfawserr.ErrCodeEquals(err, ErrCodeNoSuchTagSet) {
+		return tags, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range output.TagSet {
+		tags[aws.StringValue(t.Key)] = t.Value
+	}
+
+	return tags, nil
+}
+
+// resourceBucketInternalObjectLockRead - Only whether object lock is enabled, the default retention
+// is managed by `cortx_bucket_object_lock_configuration`
+func resourceBucketInternalObjectLockRead(ctx context.Context, client *s3.S3, d *schema.ResourceData) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	output, err := client.GetObjectLockConfigurationWithContext(ctx, &s3.GetObjectLockConfigurationInput{
+		Bucket: aws.String(d.Id()),
+	})
+
+	if NotSupportedByServer(err) {
+		log.Printf("[WARN] Unable to read object lock configuration of Bucket (%s): %v", d.Id(), err)
+		return diags
+	}
+
+	if err != nil && !tfawserr.ErrCodeEquals(err, ErrCodeObjectLockConfigurationNotFound) {
+		return append(diags, OperationErrorDiagnostic("GetObjectLockConfiguration", d.Id(), err))
+	}
+
+	d.Set("object_lock_enabled", aws.StringValue(objectLockEnabledStatus(output)) == s3.ObjectLockEnabledEnabled)
 
 	return diags
 }
 
+// expandBucketCreateConfiguration - `us-east-1` is the default location and must be omitted (mirrors
+// AWS)
+func expandBucketCreateConfiguration(region string) *s3.CreateBucketConfiguration {
+
+	if region == "" || region == endpoints.UsEast1RegionID {
+		return nil
+	}
+
+	return &s3.CreateBucketConfiguration{
+		LocationConstraint: aws.String(region),
+	}
+}
+
+// bucketRegion - A bucket's region can't change, the region in state is kept once set. Servers that
+// ignore the location constraint report the default location for every bucket, overwriting the
+// configured region w. it would plan a replacement on every run. Only imported (and unset) regions
+// are read from the server
+func bucketRegion(current, fromServer string) string {
+
+	if current != "" {
+		return current
+	}
+
+	return fromServer
+}
+
+// findBucketRegion - Reads the bucket's location constraint, an empty constraint is the default
+// location (`us-east-1`)
+func findBucketRegion(ctx context.Context, client *s3.S3, bucket string) (string, error) {
+
+	output, err := client.GetBucketLocationWithContext(ctx, &s3.GetBucketLocationInput{
+		Bucket: aws.String(bucket),
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return s3.NormalizeBucketLocation(aws.StringValue(output.LocationConstraint)), nil
+}
+
+// checkForceDestroyImpact - Counts (approximately) the objects, versions, and bytes a force destroy
+// of a replaced bucket would delete. The SDK can't attach warnings to a plan, the count is only
+// logged (visible w. TF_LOG=WARN), and the plan is refused when it exceeds `maxObjects` (0 disables
+// the guard). Failing to count is logged, never fatal for a plan
+func checkForceDestroyImpact(ctx context.Context, client *s3.S3, bucket string, maxObjects int) error {
+
+	usage, err := measureBucketUsage(ctx, client, bucket, "", forceDestroyPreviewLimit)
+
+	if err != nil {
+		log.Printf("[WARN] Unable to preview force_destroy of Bucket (%s): %v", bucket, err)
+		return nil
+	}
+
+	if usage.Versions == 0 && usage.DeleteMarkers == 0 {
+		return nil
+	}
+
+	log.Printf("[WARN] Replacing Bucket (%s) w. force_destroy enabled deletes %s", bucket, usage)
+
+	if maxObjects > 0 && usage.Versions > int64(maxObjects) {
+		return fmt.Errorf(
+			"replacing bucket (%s) would delete approximately %s, more than force_destroy_max_objects (%d). "+
+				"Raise force_destroy_max_objects or empty the bucket first", bucket, usage, maxObjects,
+		)
+	}
+
+	return nil
+}
+
 // resourceBucketUpdate -
 func resourceBucketUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
@@ -204,14 +580,15 @@ func resourceBucketUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 	// `object_lock_configuration`, leaving only `versioning` (between `website` and `acl`)
 
 	var diags diag.Diagnostics
-	client := meta.(*s3.S3)
+	client := meta.(*CortxClient).S3
+	timeout := applyTimeout(d)
 
 	if d.HasChange("versioning") {
 		v := d.Get("versioning").([]interface{})
 
 		if d.IsNewResource() {
 			if versioning := expandVersioningWhenIsNewResource(v); versioning != nil {
-				err := resourceBucketInternalVersioningUpdate(client, d.Id(), versioning)
+				err := resourceBucketInternalVersioningUpdate(ctx, client, d.Id(), versioning, timeout)
 				if err != nil {
 					// Update Diags
 					diags = append(diags, diag.Diagnostic{
@@ -223,7 +600,7 @@ func resourceBucketUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 				}
 			}
 		} else {
-			if err := resourceBucketInternalVersioningUpdate(client, d.Id(), expandVersioning(v)); err != nil {
+			if err := resourceBucketInternalVersioningUpdate(ctx, client, d.Id(), expandVersioning(v), timeout); err != nil {
 				// Update Diags
 				diags = append(diags, diag.Diagnostic{
 					Severity: diag.Error,
@@ -235,40 +612,208 @@ func resourceBucketUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 		}
 	}
 
+	if d.HasChange("tags") {
+		if err := resourceBucketInternalTagsUpdate(ctx, client, d.Id(), d.Get("tags").(map[string]interface{}), timeout); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("[ERROR] Failed on Update (%s):", d.Id()),
+				Detail:   fmt.Sprintf("[ERROR] %v", err),
+			})
+			return diags
+		}
+	}
+
 	return resourceBucketRead(ctx, d, meta)
 }
 
+// resourceBucketInternalTagsUpdate - Replaces the bucket's tag set, an empty map removes it. Returns
+// once the new tag set is consistently visible
+func resourceBucketInternalTagsUpdate(ctx context.Context, client *s3.S3, bucket string, tags map[string]interface{}, timeout time.Duration) error {
+
+	if len(tags) == 0 {
+		_, err := client.DeleteBucketTaggingWithContext(ctx, &s3.DeleteBucketTaggingInput{
+			Bucket: aws.String(bucket),
+		})
+
+		if err != nil {
+			return err
+		}
+
+		return waitForBucketTags(ctx, client, bucket, tags, timeout)
+	}
+
+	tagSet := make([]*s3.Tag, 0, len(tags))
+	for k, v := range tags {
+		tagSet = append(tagSet, &s3.Tag{Key: aws.String(k), Value: aws.String(v.(string))})
+	}
+
+	_, err := RetryWhenAWSErrCodeEqualsContext(
+		ctx,
+		timeout,
+		func() (interface{}, error) {
+			return client.PutBucketTaggingWithContext(ctx, &s3.PutBucketTaggingInput{
+				Bucket:  aws.String(bucket),
+				Tagging: &s3.Tagging{TagSet: tagSet},
+			})
+		},
+		s3.ErrCodeNoSuchBucket,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	return waitForBucketTags(ctx, client, bucket, tags, timeout)
+}
+
+// resourceBucketCustomizeDiff - Only replacements (a change to a ForceNew attribute) are checked,
+// the SDK doesn't call CustomizeDiff when planning a destroy
+func resourceBucketCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+
+	if d.Id() == "" {
+		return nil
+	}
+
+	replacedBy := ""
+	for k, v := range resourceBucket().Schema {
+		if v.ForceNew && d.HasChange(k) {
+			replacedBy = k
+			break
+		}
+	}
+
+	if replacedBy == "" {
+		return nil
+	}
+
+	// Replacing a bucket destroys it - deletion_protection (as currently applied, not as planned)
+	// blocks any change to a ForceNew attribute. Disabling protection takes a separate apply
+	if protected, _ := d.GetChange("deletion_protection"); protected.(bool) {
+		return fmt.Errorf(
+			"bucket (%s) has deletion_protection enabled, changing %q would replace (destroy) the bucket. "+
+				"Set deletion_protection = false and apply before making this change", d.Id(), replacedBy,
+		)
+	}
+
+	// The old bucket is destroyed w. the settings currently applied
+	if forceDestroy, _ := d.GetChange("force_destroy"); forceDestroy.(bool) {
+		maxObjects, _ := d.GetChange("force_destroy_max_objects")
+		return checkForceDestroyImpact(ctx, meta.(*CortxClient).S3, d.Id(), maxObjects.(int))
+	}
+
+	return nil
+}
+
 // resourceBucketDelete -
 func resourceBucketDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	var diags diag.Diagnostics
 
-	client := meta.(*s3.S3)
+	conn := meta.(*CortxClient)
+
+	// Safety latch stored in state, independent of the server (and of `prevent_destroy`)
+	if d.Get("deletion_protection").(bool) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("[ERROR] Bucket (%s) has deletion_protection enabled:", d.Id()),
+			Detail: fmt.Sprintf(
+				"[ERROR] Refusing to delete Bucket (%s). Set deletion_protection = false and apply before destroying it",
+				d.Id(),
+			),
+		})
+		return diags
+	}
+
+	backup, err := expandBucketBackup(d.Id(), d.Get("backup_on_destroy").([]interface{}))
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("[ERROR] Invalid backup_on_destroy (%s):", d.Id()),
+			Detail:   fmt.Sprintf("[ERROR] %v", err),
+		})
+		return diags
+	}
+
+	return resourceBucketInternalDelete(ctx, conn, d, backup)
+}
+
+// resourceBucketInternalDelete - Deletes the bucket, emptying it first in force destroy mode. The
+// (optional) backup is shared across passes s.t. later passes only copy objects not yet backed up
+func resourceBucketInternalDelete(ctx context.Context, conn *CortxClient, d *schema.ResourceData, backup *bucketBackup) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	client := conn.S3
 
 	_, err := client.DeleteBucketWithContext(ctx, &s3.DeleteBucketInput{
 		Bucket: aws.String(d.Id()),
 	})
 
+	// A failed audit write stops the destroy, reported alongside the outcome of DeleteBucket
+	if auditErr := conn.AuditLog.RecordDeleteBucket(d.Id(), err); auditErr != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("[ERROR] Failed on audit log (%s):", d.Id()),
+			Detail:   fmt.Sprintf("[ERROR] %v", auditErr),
+		})
+
+		if err != nil {
+			diags = append(diags, OperationErrorDiagnostic("DeleteBucket", d.Id(), err))
+		}
+
+		return diags
+	}
+
 	// Delete Bucket Not Possible - Bucket DNE
 	// This is OK - Successful "Delete" - Changed Outside of Plan
 	if tfawserr.ErrCodeEquals(err, s3.ErrCodeNoSuchBucket) {
 		return diags
 	}
 
-	// In Force Delete Mode we delete *EVERYTHING* including locked objects.
-	// CORTX object lock status ss
+	// In Force Delete Mode we delete everything that `force_destroy_object_lock_handling`
+	// allows, locked versions that stay behind are reported w. their retention and legal hold
 	if tfawserr.ErrCodeEquals(err, ErrCodeBucketNotEmpty) {
 
 		if d.Get("force_destroy").(bool) {
+			if diags := checkForceDestroyMaxObjects(ctx, client, d); diags.HasError() {
+				return diags
+			}
+
+			// Deletion only proceeds once every current object was copied
+			if backup != nil {
+				if err := backup.Run(ctx, client); err != nil {
+					diags = append(diags, diag.Diagnostic{
+						Severity: diag.Error,
+						Summary:  fmt.Sprintf("[ERROR] Failed on backup_on_destroy (%s):", d.Id()),
+						Detail:   fmt.Sprintf("[ERROR] No objects were deleted: %v", err),
+					})
+					return diags
+				}
+			}
+
 			log.Printf("[DEBUG] S3 Bucket attempting to forceDestroy %s", err)
-			if n, err := EmptyBucket(ctx, client, d.Id()); err != nil {
+			n, err := EmptyBucket(ctx, client, conn.AuditLog, d.Id(), d.Get("force_destroy_object_lock_handling").(string))
+
+			if err != nil {
 				return diag.Errorf("emptying S3 Bucket (%s): %s", d.Id(), err)
-			} else {
-				log.Printf("[DEBUG] Deleted %d S3 objects", n)
 			}
 
-			// Recurses until all objects are deleted or an error is returned
-			return resourceBucketDelete(ctx, d, meta)
+			log.Printf("[DEBUG] Deleted %d S3 objects", n)
+
+			// A pass that deletes nothing won't make DeleteBucket succeed either, e.g. the bucket holds
+			// incomplete multipart uploads, or objects the listing doesn't return
+			if n == 0 {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  fmt.Sprintf("[ERROR] Failed on forceDestroy (%s):", d.Id()),
+					Detail:   "[ERROR] DeleteBucket reports the bucket is not empty, but emptying it found no object versions to delete",
+				})
+				return diags
+			}
+
+			// Recurses until all objects are deleted, an error is returned, or a pass makes no progress
+			return resourceBucketInternalDelete(ctx, conn, d, backup)
 		}
 	}
 
@@ -284,3 +829,47 @@ func resourceBucketDelete(ctx context.Context, d *schema.ResourceData, meta inte
 
 	return diags
 }
+
+// checkForceDestroyMaxObjects - Refuses to empty a bucket holding more object versions than
+// `force_destroy_max_objects` (0 disables the guard)
+func checkForceDestroyMaxObjects(ctx context.Context, client *s3.S3, d *schema.ResourceData) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	maxObjects := d.Get("force_destroy_max_objects").(int)
+	if maxObjects == 0 {
+		return diags
+	}
+
+	// Count past the threshold (up to the preview limit) s.t. the refusal shows the approximate size
+	limit := int64(maxObjects) + 1
+	if limit < forceDestroyPreviewLimit {
+		limit = forceDestroyPreviewLimit
+	}
+
+	usage, err := measureBucketUsage(ctx, client, d.Id(), "", limit)
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("[ERROR] Failed counting objects before forceDestroy (%s):", d.Id()),
+			Detail:   fmt.Sprintf("[ERROR] %v", err),
+		})
+		return diags
+	}
+
+	if usage.Versions > int64(maxObjects) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("[ERROR] Refusing to forceDestroy Bucket (%s):", d.Id()),
+			Detail: fmt.Sprintf(
+				"[ERROR] Bucket holds approximately %s, more than force_destroy_max_objects (%d). No objects were deleted. "+
+					"Raise force_destroy_max_objects or empty the bucket first",
+				usage, maxObjects,
+			),
+		})
+		return diags
+	}
+
+	return diags
+}
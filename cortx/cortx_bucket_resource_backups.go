@@ -0,0 +1,500 @@
+package cortx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	multierror "github.com/hashicorp/go-multierror"
+	"io"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+//
+// NOTE: Backs up a bucket's current objects before a force destroy empties it, either by server
+// side copy to another bucket or by download to a local directory
+//
+
+// backupManifestName - Written under the backup prefix once every object has been copied
+const backupManifestName = ".backup-manifest.json"
+
+// backupConcurrency - Number of objects copied (or downloaded) in parallel during a backup
+const backupConcurrency = 8
+
+// maxCopyObjectSize - CopyObject copies objects up to 5 GiB, larger objects are copied in parts
+const maxCopyObjectSize = 5 * 1024 * 1024 * 1024
+
+// backupCopyPartSize - Part size of multipart copies, 10,000 parts (the maximum) cover 5 TiB
+const backupCopyPartSize = 512 * 1024 * 1024
+
+// bucketBackup is the `backup_on_destroy` configuration of a bucket, w. the default prefix resolved. Entries
+// accumulate across runs s.t. repeated force destroy passes only copy objects that weren't backed up yet, and write a
+// single manifest.
+type bucketBackup struct {
+	SourceBucket string
+	TargetBucket string
+	LocalPath    string
+	Prefix       string
+
+	entries         map[string]*backupManifestEntry
+	manifestWritten bool
+}
+
+// backupManifest -
+type backupManifest struct {
+	SourceBucket string                 `json:"source_bucket"`
+	Target       string                 `json:"target"`
+	CompletedAt  string                 `json:"completed_at"`
+	Objects      []*backupManifestEntry `json:"objects"`
+}
+
+// backupManifestEntry -
+type backupManifestEntry struct {
+	Key             string            `json:"key"`
+	SourceVersionID string            `json:"source_version_id,omitempty"`
+	Target          string            `json:"target"`
+	ETag            string            `json:"etag"`
+	Size            int64             `json:"size"`
+	LastModified    string            `json:"last_modified"`
+	Metadata        map[string]string `json:"metadata,omitempty"`
+	Tags            map[string]string `json:"tags,omitempty"`
+}
+
+// expandBucketBackup - Returns nil when `backup_on_destroy` isn't configured. The default prefix is
+// `<bucket>/<timestamp>/` s.t. repeated teardowns of the same bucket don't overwrite each other
+func expandBucketBackup(bucket string, l []interface{}) (*bucketBackup, error) {
+
+	if len(l) == 0 || l[0] == nil {
+		return nil, nil
+	}
+
+	tfMap := l[0].(map[string]interface{})
+
+	backup := &bucketBackup{
+		SourceBucket: bucket,
+		TargetBucket: tfMap["bucket"].(string),
+		LocalPath:    tfMap["local_path"].(string),
+		Prefix:       tfMap["prefix"].(string),
+		entries:      map[string]*backupManifestEntry{},
+	}
+
+	if backup.TargetBucket == bucket {
+		return nil, fmt.Errorf("backup_on_destroy bucket must differ from the bucket being destroyed (%s)", bucket)
+	}
+
+	if backup.Prefix == "" {
+		backup.Prefix = fmt.Sprintf("%s/%s/", bucket, time.Now().UTC().Format("20060102T150405Z"))
+	}
+
+	return backup, nil
+}
+
+// Target -
+func (b *bucketBackup) Target() string {
+	if b.TargetBucket != "" {
+		return fmt.Sprintf("s3://%s/%s", b.TargetBucket, b.Prefix)
+	}
+	return filepath.Join(b.LocalPath, filepath.FromSlash(b.Prefix))
+}
+
+// Run copies every current object of the source bucket to the target and then writes the manifest. Any failed
+// object fails the backup as a whole, and the manifest is only written after every object was copied. Objects already
+// in the manifest (same key and ETag) are skipped.
+func (b *bucketBackup) Run(ctx context.Context, client *s3.S3) error {
+
+	var (
+		mu         sync.Mutex
+		backupErrs *multierror.Error
+		wg         sync.WaitGroup
+		nObjects   int
+	)
+
+	objects := make(chan *s3.Object, backupConcurrency)
+
+	for i := 0; i < backupConcurrency; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for object := range objects {
+				entry, err := b.backupObject(ctx, client, object)
+
+				mu.Lock()
+				if err != nil {
+					backupErrs = multierror.Append(backupErrs, err)
+				} else {
+					b.entries[entry.Key] = entry
+					nObjects++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	listErr := client.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(b.SourceBucket),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, object := range page.Contents {
+			mu.Lock()
+			entry, ok := b.entries[aws.StringValue(object.Key)]
+			mu.Unlock()
+
+			if ok && entry.ETag == strings.Trim(aws.StringValue(object.ETag), `"`) {
+				continue
+			}
+
+			select {
+			case objects <- object:
+			case <-ctx.Done():
+				return false
+			}
+		}
+		return !lastPage
+	})
+
+	close(objects)
+	wg.Wait()
+
+	if listErr != nil {
+		backupErrs = multierror.Append(backupErrs, fmt.Errorf("listing S3 Bucket (%s) objects: %w", b.SourceBucket, listErr))
+	}
+
+	if err := ctx.Err(); err != nil {
+		backupErrs = multierror.Append(backupErrs, err)
+	}
+
+	if err := backupErrs.ErrorOrNil(); err != nil {
+		return fmt.Errorf("backing up S3 Bucket (%s) to %s: %w", b.SourceBucket, b.Target(), err)
+	}
+
+	log.Printf("[INFO] Backed up %d objects from S3 Bucket (%s) to %s", nObjects, b.SourceBucket, b.Target())
+
+	if nObjects == 0 && b.manifestWritten {
+		return nil
+	}
+
+	if err := b.writeManifest(ctx, client); err != nil {
+		return err
+	}
+
+	b.manifestWritten = true
+	return nil
+}
+
+// backupObject -
+func (b *bucketBackup) backupObject(ctx context.Context, client *s3.S3, object *s3.Object) (*backupManifestEntry, error) {
+
+	key := aws.StringValue(object.Key)
+
+	entry := &backupManifestEntry{
+		Key:          key,
+		ETag:         strings.Trim(aws.StringValue(object.ETag), `"`),
+		Size:         aws.Int64Value(object.Size),
+		LastModified: aws.TimeValue(object.LastModified).Format(time.RFC3339),
+	}
+
+	if b.TargetBucket != "" {
+		return entry, b.copyObject(ctx, client, entry)
+	}
+
+	return entry, b.downloadObject(ctx, client, entry)
+}
+
+// copyObject - Server side copy, metadata and tags are copied along w. the object (the default
+// COPY directives). Objects larger than CopyObject allows are copied in parts
+func (b *bucketBackup) copyObject(ctx context.Context, client *s3.S3, entry *backupManifestEntry) error {
+
+	targetKey := b.Prefix + entry.Key
+	entry.Target = fmt.Sprintf("s3://%s/%s", b.TargetBucket, targetKey)
+
+	if entry.Size > maxCopyObjectSize {
+		return b.copyObjectInParts(ctx, client, entry, targetKey)
+	}
+
+	output, err := client.CopyObjectWithContext(ctx, &s3.CopyObjectInput{
+		Bucket:     aws.String(b.TargetBucket),
+		Key:        aws.String(targetKey),
+		CopySource: aws.String(copySource(b.SourceBucket, entry.Key)),
+	})
+
+	if err != nil {
+		return fmt.Errorf("copying: %w", newObjectVersionError(entry.Key, "", err))
+	}
+
+	entry.SourceVersionID = aws.StringValue(output.CopySourceVersionId)
+
+	return nil
+}
+
+// copyObjectInParts - Multipart copy (UploadPartCopy) pinned to the version seen by HeadObject.
+// Unlike CopyObject a multipart upload doesn't carry metadata and tags over, they're read from
+// the source and set when the upload is created. A failed copy aborts the upload
+func (b *bucketBackup) copyObjectInParts(ctx context.Context, client *s3.S3, entry *backupManifestEntry, targetKey string) error {
+
+	head, err := client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(b.SourceBucket),
+		Key:    aws.String(entry.Key),
+	})
+
+	if err != nil {
+		return fmt.Errorf("copying: %w", newObjectVersionError(entry.Key, "", err))
+	}
+
+	entry.SourceVersionID = aws.StringValue(head.VersionId)
+	source := copySource(b.SourceBucket, entry.Key)
+
+	if entry.SourceVersionID != "" && entry.SourceVersionID != "null" {
+		source += "?versionId=" + url.QueryEscape(entry.SourceVersionID)
+	}
+
+	input := &s3.CreateMultipartUploadInput{
+		Bucket:             aws.String(b.TargetBucket),
+		Key:                aws.String(targetKey),
+		CacheControl:       head.CacheControl,
+		ContentDisposition: head.ContentDisposition,
+		ContentEncoding:    head.ContentEncoding,
+		ContentLanguage:    head.ContentLanguage,
+		ContentType:        head.ContentType,
+		Metadata:           head.Metadata,
+	}
+
+	tagging, err := client.GetObjectTaggingWithContext(ctx, &s3.GetObjectTaggingInput{
+		Bucket:    aws.String(b.SourceBucket),
+		Key:       aws.String(entry.Key),
+		VersionId: head.VersionId,
+	})
+
+	if err != nil && !NotSupportedByServer(err) {
+		return fmt.Errorf("getting tags: %w", newObjectVersionError(entry.Key, entry.SourceVersionID, err))
+	}
+
+	if tagging != nil && len(tagging.TagSet) > 0 {
+		tags := url.Values{}
+		for _, t := range tagging.TagSet {
+			tags.Set(aws.StringValue(t.Key), aws.StringValue(t.Value))
+		}
+		input.Tagging = aws.String(tags.Encode())
+	}
+
+	upload, err := client.CreateMultipartUploadWithContext(ctx, input)
+
+	if err != nil {
+		return fmt.Errorf("copying: %w", newObjectVersionError(entry.Key, entry.SourceVersionID, err))
+	}
+
+	var parts []*s3.CompletedPart
+
+	for i, byteRange := range copyPartRanges(aws.Int64Value(head.ContentLength), backupCopyPartSize) {
+		output, err := client.UploadPartCopyWithContext(ctx, &s3.UploadPartCopyInput{
+			Bucket:          aws.String(b.TargetBucket),
+			Key:             aws.String(targetKey),
+			UploadId:        upload.UploadId,
+			PartNumber:      aws.Int64(int64(i + 1)),
+			CopySource:      aws.String(source),
+			CopySourceRange: aws.String(byteRange),
+		})
+
+		if err != nil {
+			return b.abortCopy(ctx, client, entry, targetKey, upload.UploadId, err)
+		}
+
+		parts = append(parts, &s3.CompletedPart{
+			ETag:       output.CopyPartResult.ETag,
+			PartNumber: aws.Int64(int64(i + 1)),
+		})
+	}
+
+	_, err = client.CompleteMultipartUploadWithContext(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(b.TargetBucket),
+		Key:             aws.String(targetKey),
+		UploadId:        upload.UploadId,
+		MultipartUpload: &s3.CompletedMultipartUpload{Parts: parts},
+	})
+
+	if err != nil {
+		return b.abortCopy(ctx, client, entry, targetKey, upload.UploadId, err)
+	}
+
+	return nil
+}
+
+// abortCopy - Aborts a failed multipart copy s.t. its parts don't linger in the target bucket
+func (b *bucketBackup) abortCopy(ctx context.Context, client *s3.S3, entry *backupManifestEntry, targetKey string, uploadID *string, err error) error {
+
+	if _, abortErr := client.AbortMultipartUploadWithContext(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(b.TargetBucket),
+		Key:      aws.String(targetKey),
+		UploadId: uploadID,
+	}); abortErr != nil {
+		log.Printf("[WARN] Unable to abort multipart copy of S3 object (%s) to %s: %v", entry.Key, entry.Target, abortErr)
+	}
+
+	return fmt.Errorf("copying: %w", newObjectVersionError(entry.Key, entry.SourceVersionID, err))
+}
+
+// downloadObject - Metadata and tags can't be stored alongside a local file, they're recorded in
+// the manifest instead
+func (b *bucketBackup) downloadObject(ctx context.Context, client *s3.S3, entry *backupManifestEntry) error {
+
+	path, err := b.localPath(entry.Key)
+	if err != nil {
+		return err
+	}
+
+	entry.Target = path
+
+	// Keys ending in `/` are folder placeholders, nothing to download
+	if strings.HasSuffix(entry.Key, "/") {
+		return os.MkdirAll(path, 0o755)
+	}
+
+	output, err := client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(b.SourceBucket),
+		Key:    aws.String(entry.Key),
+	})
+
+	if err != nil {
+		return fmt.Errorf("downloading: %w", newObjectVersionError(entry.Key, "", err))
+	}
+
+	defer output.Body.Close()
+
+	entry.SourceVersionID = aws.StringValue(output.VersionId)
+	entry.Metadata = map[string]string{}
+
+	for k, v := range output.Metadata {
+		entry.Metadata[k] = aws.StringValue(v)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(f, output.Body); err != nil {
+		f.Close()
+		return fmt.Errorf("downloading: %w", newObjectVersionError(entry.Key, entry.SourceVersionID, err))
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	tagging, err := client.GetObjectTaggingWithContext(ctx, &s3.GetObjectTaggingInput{
+		Bucket:    aws.String(b.SourceBucket),
+		Key:       aws.String(entry.Key),
+		VersionId: output.VersionId,
+	})
+
+	if err != nil && !NotSupportedByServer(err) {
+		return fmt.Errorf("getting tags: %w", newObjectVersionError(entry.Key, entry.SourceVersionID, err))
+	}
+
+	if tagging != nil && len(tagging.TagSet) > 0 {
+		entry.Tags = map[string]string{}
+		for _, t := range tagging.TagSet {
+			entry.Tags[aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+		}
+	}
+
+	return nil
+}
+
+// localPath - Maps an object key below the backup directory, refusing keys that would escape it
+// (e.g. `../../etc/passwd`)
+func (b *bucketBackup) localPath(key string) (string, error) {
+
+	root := b.Target()
+	path := filepath.Join(root, filepath.FromSlash(key))
+
+	if rel, err := filepath.Rel(root, path); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("object key (%s) resolves outside of the backup directory (%s)", key, root)
+	}
+
+	return path, nil
+}
+
+// writeManifest -
+func (b *bucketBackup) writeManifest(ctx context.Context, client *s3.S3) error {
+
+	manifest := &backupManifest{
+		SourceBucket: b.SourceBucket,
+		Target:       b.Target(),
+		CompletedAt:  time.Now().UTC().Format(time.RFC3339),
+		Objects:      make([]*backupManifestEntry, 0, len(b.entries)),
+	}
+
+	for _, entry := range b.entries {
+		manifest.Objects = append(manifest.Objects, entry)
+	}
+
+	sort.Slice(manifest.Objects, func(i, j int) bool {
+		return manifest.Objects[i].Key < manifest.Objects[j].Key
+	})
+
+	body, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if b.TargetBucket != "" {
+		_, err = client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+			Bucket:      aws.String(b.TargetBucket),
+			Key:         aws.String(b.Prefix + backupManifestName),
+			Body:        bytes.NewReader(body),
+			ContentType: aws.String("application/json"),
+		})
+	} else {
+		if err = os.MkdirAll(b.Target(), 0o755); err == nil {
+			err = os.WriteFile(filepath.Join(b.Target(), backupManifestName), body, 0o644)
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("writing backup manifest for S3 Bucket (%s) to %s: %w", b.SourceBucket, b.Target(), err)
+	}
+
+	return nil
+}
+
+// copyPartRanges - The `bytes=first-last` ranges of a multipart copy of `size` bytes
+func copyPartRanges(size, partSize int64) []string {
+
+	var ranges []string
+
+	for start := int64(0); start < size; start += partSize {
+		end := start + partSize - 1
+		if end >= size {
+			end = size - 1
+		}
+		ranges = append(ranges, fmt.Sprintf("bytes=%d-%d", start, end))
+	}
+
+	return ranges
+}
+
+// copySource - URL encodes `bucket/key` for CopyObject, segment by segment s.t. the separators survive.
+// PathEscape leaves `+` as is, which S3-compatible servers decode as a space in x-amz-copy-source
+func copySource(bucket, key string) string {
+
+	segments := strings.Split(key, "/")
+	for i, s := range segments {
+		segments[i] = strings.ReplaceAll(url.PathEscape(s), "+", "%2B")
+	}
+
+	return bucket + "/" + strings.Join(segments, "/")
+}
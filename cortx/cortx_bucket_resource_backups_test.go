@@ -0,0 +1,58 @@
+package cortx
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCopySource(t *testing.T) {
+
+	cases := []struct {
+		key      string
+		expected string
+	}{
+		{"a.txt", "src/a.txt"},
+		{"logs/2022 06/app.log", "src/logs/2022%2006/app.log"},
+		{"logs/app+1.log", "src/logs/app%2B1.log"},
+		{"c++/a b+c", "src/c%2B%2B/a%20b%2Bc"},
+	}
+
+	for _, tc := range cases {
+		if got := copySource("src", tc.key); got != tc.expected {
+			t.Errorf("%s: expected %s, got %s", tc.key, tc.expected, got)
+		}
+	}
+}
+
+func TestBucketBackupLocalPath(t *testing.T) {
+
+	backup := &bucketBackup{SourceBucket: "src", LocalPath: "/backups", Prefix: "src/run/"}
+
+	path, err := backup.localPath("a/b.txt")
+	if err != nil || path != filepath.FromSlash("/backups/src/run/a/b.txt") {
+		t.Errorf("unexpected local path: %s (%v)", path, err)
+	}
+
+	if _, err := backup.localPath("../../../etc/passwd"); err == nil {
+		t.Error("expected key escaping the backup directory to be rejected")
+	}
+}
+
+func TestCopyPartRanges(t *testing.T) {
+
+	got := copyPartRanges(25, 10)
+	expected := []string{"bytes=0-9", "bytes=10-19", "bytes=20-24"}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	if got := copyPartRanges(20, 10); len(got) != 2 || got[1] != "bytes=10-19" {
+		t.Errorf("expected 2 full parts, got %v", got)
+	}
+
+	if got := copyPartRanges(maxCopyObjectSize+1, backupCopyPartSize); len(got) != 11 {
+		t.Errorf("expected 11 parts, got %d", len(got))
+	}
+}
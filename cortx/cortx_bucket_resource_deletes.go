@@ -8,6 +8,10 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/hashicorp/aws-sdk-go-base/v2/awsv1shim/v2/tfawserr"
 	multierror "github.com/hashicorp/go-multierror"
+	"log"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -15,32 +19,416 @@ import (
 // NOTE: Mostly Put Together With Bits of the AWS Provider - Truncated Where Possible
 //
 
-// EmptyBucket empties the specified S3 bucket by deleting all object versions and delete markers.
-// NOTE: Crudely Bypasses All Object Lock Configurations
-func EmptyBucket(ctx context.Context, client *s3.S3, bucket string) (int64, error) {
+// Object lock handling modes for `force_destroy_object_lock_handling`. COMPLIANCE mode retention
+// can't be bypassed under any mode.
+const (
+	// ObjectLockHandlingRespect - Leave every locked object version in place (and report it)
+	ObjectLockHandlingRespect = "respect"
+	// ObjectLockHandlingBypassGovernance - Bypass GOVERNANCE retention, refuse on legal holds
+	ObjectLockHandlingBypassGovernance = "bypass_governance"
+	// ObjectLockHandlingRemoveLegalHolds - Bypass GOVERNANCE retention and remove legal holds
+	ObjectLockHandlingRemoveLegalHolds = "remove_legal_holds"
+)
+
+// emptyBucketConcurrency - Number of DeleteObjects batches in flight while emptying a bucket
+const emptyBucketConcurrency = 8
+
+// emptyBucketProgressInterval - Log progress every N deleted batches while emptying a bucket
+const emptyBucketProgressInterval = 10
+
+// maxReportedLockedVersions - Caps the number of locked versions listed in an error
+const maxReportedLockedVersions = 50
+
+// lockedObjectVersion is an S3 object version protected by a retention period or a legal hold.
+type lockedObjectVersion struct {
+	Key         string
+	VersionID   string
+	Mode        string
+	RetainUntil time.Time
+	LegalHold   bool
+}
+
+// EmptyBucket empties the specified S3 bucket by deleting all object versions and delete markers in a single listing
+// pass. In buckets w. object lock enabled the locks of every version are checked up front, nothing is deleted while a
+// version is under COMPLIANCE mode retention or otherwise locked in a way `lockHandling` doesn't allow removing.
+func EmptyBucket(ctx context.Context, client *s3.S3, audit *AuditLog, bucket string, lockHandling string) (int64, error) {
+
+	lockEnabled, err := bucketObjectLockEnabled(ctx, client, bucket)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if lockEnabled {
+		locked, err := findLockedObjectVersions(ctx, client, bucket)
+
+		if err != nil {
+			return 0, fmt.Errorf("checking object locks before emptying S3 Bucket (%s), no objects were deleted: %w", bucket, err)
+		}
+
+		if err := checkLockedObjectVersions(bucket, locked, lockHandling); err != nil {
+			return 0, err
+		}
+	}
+
+	return deleteAllObjectVersions(ctx, client, audit, bucket, lockHandling)
+}
+
+// findLockedObjectVersions lists the bucket's object versions and looks up the lock of each one w. a bounded pool of
+// workers. Delete markers can't be locked and aren't looked up.
+func findLockedObjectVersions(ctx context.Context, client *s3.S3, bucket string) ([]*lockedObjectVersion, error) {
+
+	var (
+		mu        sync.Mutex
+		lookupErr *multierror.Error
+		locked    []*lockedObjectVersion
+		wg        sync.WaitGroup
+	)
+
+	now := time.Now()
+	versions := make(chan *s3.ObjectVersion, emptyBucketConcurrency)
+
+	for i := 0; i < emptyBucketConcurrency; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for v := range versions {
+				version, err := findObjectVersionLock(ctx, client, bucket, aws.StringValue(v.Key), aws.StringValue(v.VersionId), now)
+
+				if err != nil || version != nil {
+					mu.Lock()
+					if err != nil {
+						lookupErr = multierror.Append(lookupErr, err)
+					} else {
+						locked = append(locked, version)
+					}
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	_, listErr := forEachObjectVersionsPage(ctx, client, bucket, "", func(ctx context.Context, client *s3.S3, bucket string, page *s3.ListObjectVersionsOutput) (int64, error) {
+		for _, v := range page.Versions {
+			select {
+			case versions <- v:
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			}
+		}
+
+		return 0, nil
+	})
+
+	close(versions)
+	wg.Wait()
+
+	if listErr != nil {
+		lookupErr = multierror.Append(lookupErr, listErr)
+	}
+
+	return locked, lookupErr.ErrorOrNil()
+}
+
+// deleteAllObjectVersions lists the bucket's object versions and delete markers once, handing each page to a bounded
+// pool of workers as a single DeleteObjects batch. Listing continues past failed batches s.t. every version that can't
+// be deleted is reported. Locks are only looked up for the versions DeleteObjects refuses.
+func deleteAllObjectVersions(ctx context.Context, client *s3.S3, audit *AuditLog, bucket string, lockHandling string) (int64, error) {
+
+	var (
+		nObjects   int64
+		nBatches   int64
+		mu         sync.Mutex
+		deleteErrs *multierror.Error
+		locked     []*lockedObjectVersion
+		wg         sync.WaitGroup
+	)
+
+	batches := make(chan []*s3.ObjectIdentifier, emptyBucketConcurrency)
+
+	for i := 0; i < emptyBucketConcurrency; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for batch := range batches {
+				n, batchLocked, err := deleteBatchOfLockedObjectVersions(ctx, client, audit, bucket, batch, lockHandling)
+
+				total := atomic.AddInt64(&nObjects, n)
+				if b := atomic.AddInt64(&nBatches, 1); b%emptyBucketProgressInterval == 0 {
+					log.Printf("[INFO] Emptying S3 Bucket (%s): deleted %d object versions in %d batches", bucket, total, b)
+				}
+
+				if err != nil || len(batchLocked) > 0 {
+					mu.Lock()
+					if err != nil {
+						deleteErrs = multierror.Append(deleteErrs, err)
+					}
+					locked = append(locked, batchLocked...)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	_, listErr := forEachObjectVersionsPage(ctx, client, bucket, "", func(ctx context.Context, client *s3.S3, bucket string, page *s3.ListObjectVersionsOutput) (int64, error) {
+		batch := objectIdentifiersOfPage(page)
 
-	// Delete Object Versions
-	nObjects, err := forEachObjectVersionsPage(ctx, client, bucket, func(ctx context.Context, client *s3.S3, bucket string, page *s3.ListObjectVersionsOutput) (int64, error) {
-		return deletePageOfObjectVersions(ctx, client, bucket, page)
+		if len(batch) == 0 {
+			return 0, nil
+		}
+
+		select {
+		case batches <- batch:
+			return 0, nil
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
 	})
 
+	close(batches)
+	wg.Wait()
+
+	log.Printf("[INFO] Emptied S3 Bucket (%s): deleted %d object versions in %d batches", bucket, nObjects, nBatches)
+
+	if listErr != nil {
+		deleteErrs = multierror.Append(deleteErrs, listErr)
+	}
+
+	if err := checkLockedObjectVersions(bucket, locked, lockHandling); err != nil {
+		deleteErrs = multierror.Append(deleteErrs, err)
+	}
+
+	return nObjects, deleteErrs.ErrorOrNil()
+}
+
+// deleteBatchOfLockedObjectVersions deletes a batch of object versions under `lockHandling`. Versions refused w.
+// AccessDenied are looked up w. HeadObject, those only held by a legal hold are released and retried when
+// `lockHandling` allows it. Returns the versions left in place because of a lock.
+func deleteBatchOfLockedObjectVersions(ctx context.Context, client *s3.S3, audit *AuditLog, bucket string, toDelete []*s3.ObjectIdentifier, lockHandling string) (int64, []*lockedObjectVersion, error) {
+
+	var (
+		locked     []*lockedObjectVersion
+		retry      []*s3.ObjectIdentifier
+		deleteErrs *multierror.Error
+	)
+
+	bypassGovernance := lockHandling != ObjectLockHandlingRespect
+
+	nObjects, denied, err := deleteObjectVersions(ctx, client, audit, bucket, toDelete, bypassGovernance)
+
 	if err != nil {
-		return nObjects, err
+		deleteErrs = multierror.Append(deleteErrs, err)
 	}
 
-	// Delete Object Version Delete Markers
-	n, err := forEachObjectVersionsPage(ctx, client, bucket, deletePageOfDeleteMarkers)
-	nObjects += n
+	now := time.Now()
+
+	for _, v := range denied {
+		key, versionID := aws.StringValue(v.Key), aws.StringValue(v.VersionId)
+
+		version, err := findObjectVersionLock(ctx, client, bucket, key, versionID, now)
+
+		if err != nil {
+			deleteErrs = multierror.Append(deleteErrs, err)
+			continue
+		}
+
+		// Refused, but not locked - A plain permissions error
+		if version == nil {
+			deleteErrs = multierror.Append(deleteErrs, newDeleteObjectVersionError(v))
+			continue
+		}
+
+		switch {
+		case version.BlockedBy(lockHandling):
+			locked = append(locked, version)
+			continue
+		case !version.LegalHold:
+			// Refused although `lockHandling` allows removing it - e.g. s3:BypassGovernanceRetention isn't granted
+			deleteErrs = multierror.Append(deleteErrs, newDeleteObjectVersionError(v))
+			continue
+		}
+
+		// Only held by a legal hold `lockHandling` allows removing
+
+		if err := removeObjectLegalHold(ctx, client, audit, bucket, key, versionID); err != nil {
+			deleteErrs = multierror.Append(deleteErrs, err)
+			continue
+		}
+
+		retry = append(retry, &s3.ObjectIdentifier{Key: v.Key, VersionId: v.VersionId})
+	}
+
+	if len(retry) > 0 {
+		n, err := deleteBatchOfObjectVersions(ctx, client, audit, bucket, retry, bypassGovernance)
+		nObjects += n
+
+		if err != nil {
+			deleteErrs = multierror.Append(deleteErrs, err)
+		}
+	}
+
+	return nObjects, locked, deleteErrs.ErrorOrNil()
+}
+
+// bucketObjectLockEnabled reports whether the bucket was created w. object lock enabled. Servers that don't
+// implement the object lock APIs can't hold locked objects.
+func bucketObjectLockEnabled(ctx context.Context, client *s3.S3, bucket string) (bool, error) {
+
+	output, err := client.GetObjectLockConfigurationWithContext(ctx, &s3.GetObjectLockConfigurationInput{
+		Bucket: aws.String(bucket),
+	})
+
+	if tfawserr.ErrCodeEquals(err, ErrCodeObjectLockConfigurationNotFound) || NotSupportedByServer(err) {
+		return false, nil
+	}
 
 	if err != nil {
-		return nObjects, err
+		return false, fmt.Errorf("getting S3 Bucket (%s) object lock configuration: %w", bucket, err)
 	}
 
-	return nObjects, nil
+	return aws.StringValue(objectLockEnabledStatus(output)) == s3.ObjectLockEnabledEnabled, nil
+}
+
+// findObjectVersionLock returns the retention and legal hold of a single object version, nil when it's not locked.
+func findObjectVersionLock(ctx context.Context, client *s3.S3, bucket, key, versionID string, now time.Time) (*lockedObjectVersion, error) {
+
+	output, err := client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket:    aws.String(bucket),
+		Key:       aws.String(key),
+		VersionId: aws.String(versionID),
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("checking object lock: %w", newObjectVersionError(key, versionID, err))
+	}
+
+	version := &lockedObjectVersion{
+		Key:       key,
+		VersionID: versionID,
+		LegalHold: aws.StringValue(output.ObjectLockLegalHoldStatus) == s3.ObjectLockLegalHoldStatusOn,
+	}
+
+	if retainUntil := aws.TimeValue(output.ObjectLockRetainUntilDate); retainUntil.After(now) {
+		version.Mode = aws.StringValue(output.ObjectLockMode)
+		version.RetainUntil = retainUntil
+	}
+
+	if !version.LegalHold && version.Mode == "" {
+		return nil, nil
+	}
+
+	return version, nil
+}
+
+// checkLockedObjectVersions returns an error listing the locked versions that `lockHandling` doesn't allow
+// removing. COMPLIANCE mode versions are reported first, these block deletion under every mode.
+//
+// NOTE: EmptyBucket checks before deleting anything. Versions locked after that check (or in buckets whose object lock
+// status couldn't be seen) are only found by the deleting pass, w. the removable versions already deleted
+func checkLockedObjectVersions(bucket string, locked []*lockedObjectVersion, lockHandling string) error {
+
+	var compliance, blocked []*lockedObjectVersion
+
+	for _, v := range locked {
+		switch {
+		case v.Mode == s3.ObjectLockModeCompliance:
+			compliance = append(compliance, v)
+		case v.BlockedBy(lockHandling):
+			blocked = append(blocked, v)
+		}
+	}
+
+	if len(compliance) == 0 && len(blocked) == 0 {
+		return nil
+	}
+
+	var b strings.Builder
+
+	if len(compliance) > 0 {
+		fmt.Fprintf(&b, "%d object versions are under COMPLIANCE mode retention, which can't be bypassed; ", len(compliance))
+	}
+
+	if len(blocked) > 0 {
+		fmt.Fprintf(&b, "%d object versions are locked and force_destroy_object_lock_handling = %q doesn't allow removing them; ", len(blocked), lockHandling)
+	}
+
+	b.WriteString("the bucket was not emptied. Locked versions:")
+
+	for i, v := range append(compliance, blocked...) {
+		if i == maxReportedLockedVersions {
+			fmt.Fprintf(&b, "\n  ... and %d more", len(compliance)+len(blocked)-maxReportedLockedVersions)
+			break
+		}
+		fmt.Fprintf(&b, "\n  %s", v)
+	}
+
+	return fmt.Errorf("emptying S3 Bucket (%s): %s", bucket, b.String())
+}
+
+// BlockedBy reports whether `lockHandling` leaves the version in place.
+func (v *lockedObjectVersion) BlockedBy(lockHandling string) bool {
+	switch {
+	case v.Mode == s3.ObjectLockModeCompliance:
+		return true
+	case v.LegalHold && lockHandling != ObjectLockHandlingRemoveLegalHolds:
+		return true
+	case v.Mode == s3.ObjectLockModeGovernance && lockHandling == ObjectLockHandlingRespect:
+		return true
+	}
+	return false
+}
+
+// String -
+func (v *lockedObjectVersion) String() string {
+
+	var locks []string
+
+	if v.Mode != "" {
+		locks = append(locks, fmt.Sprintf("%s retention until %s", v.Mode, v.RetainUntil.UTC().Format(time.RFC3339)))
+	}
+
+	if v.LegalHold {
+		locks = append(locks, "legal hold")
+	}
+
+	return fmt.Sprintf("%s (version %s): %s", v.Key, v.VersionID, strings.Join(locks, ", "))
+}
+
+// removeObjectLegalHold turns off the legal hold on a single object version.
+func removeObjectLegalHold(ctx context.Context, client *s3.S3, audit *AuditLog, bucket, key, versionID string) error {
+
+	log.Printf("[WARN] Removing legal hold from S3 Bucket (%s) object (%s) version (%s)", bucket, key, versionID)
+
+	_, err := client.PutObjectLegalHoldWithContext(ctx, &s3.PutObjectLegalHoldInput{
+		Bucket:    aws.String(bucket),
+		Key:       aws.String(key),
+		VersionId: aws.String(versionID),
+		LegalHold: &s3.ObjectLockLegalHold{
+			Status: aws.String(s3.ObjectLockLegalHoldStatusOff),
+		},
+	})
+
+	auditErr := audit.RecordRemoveLegalHold(bucket, key, versionID, err)
+
+	if err != nil {
+		err = fmt.Errorf("removing legal hold: %w", newObjectVersionError(key, versionID, err))
+	}
+
+	// A failed audit write is reported alongside the outcome, never in its place
+	if auditErr != nil {
+		return multierror.Append(err, auditErr)
+	}
+
+	return err
 }
 
 // forEachObjectVersionsPage calls the specified function for each page returned from the S3 ListObjectVersionsPages API.
-func forEachObjectVersionsPage(ctx context.Context, client *s3.S3, bucket string, fn func(ctx context.Context, client *s3.S3, bucket string, page *s3.ListObjectVersionsOutput) (int64, error)) (int64, error) {
+// An empty prefix lists every object version in the bucket.
+func forEachObjectVersionsPage(ctx context.Context, client *s3.S3, bucket string, prefix string, fn func(ctx context.Context, client *s3.S3, bucket string, page *s3.ListObjectVersionsOutput) (int64, error)) (int64, error) {
 
 	var (
 		nObjects int64
@@ -51,6 +439,10 @@ func forEachObjectVersionsPage(ctx context.Context, client *s3.S3, bucket string
 		Bucket: aws.String(bucket),
 	}
 
+	if prefix != "" {
+		input.Prefix = aws.String(prefix)
+	}
+
 	err := client.ListObjectVersionsPagesWithContext(ctx, input, func(page *s3.ListObjectVersionsOutput, lastPage bool) bool {
 		if page == nil {
 			return !lastPage
@@ -60,6 +452,7 @@ func forEachObjectVersionsPage(ctx context.Context, client *s3.S3, bucket string
 		nObjects += n
 
 		if err != nil {
+			lastErr = err
 			return false
 		}
 
@@ -77,15 +470,11 @@ func forEachObjectVersionsPage(ctx context.Context, client *s3.S3, bucket string
 	return nObjects, nil
 }
 
-// deletePageOfObjectVersions deletes a page (<= 1000) of S3 object versions.
-func deletePageOfObjectVersions(ctx context.Context, client *s3.S3, bucket string, page *s3.ListObjectVersionsOutput) (int64, error) {
-
-	var (
-		nObjects   int64
-		deleteErrs *multierror.Error
-	)
+// objectIdentifiersOfPage returns the object versions and delete markers of a page (<= 1000 combined) as a single
+// DeleteObjects batch.
+func objectIdentifiersOfPage(page *s3.ListObjectVersionsOutput) []*s3.ObjectIdentifier {
 
-	toDelete := make([]*s3.ObjectIdentifier, 0, len(page.Versions))
+	toDelete := make([]*s3.ObjectIdentifier, 0, len(page.Versions)+len(page.DeleteMarkers))
 
 	for _, v := range page.Versions {
 		toDelete = append(toDelete, &s3.ObjectIdentifier{
@@ -94,132 +483,96 @@ func deletePageOfObjectVersions(ctx context.Context, client *s3.S3, bucket strin
 		})
 	}
 
-	if nObjects = int64(len(toDelete)); nObjects == 0 {
-		return nObjects, nil
-	}
-
-	// Delete Objects -
-	output, err := client.DeleteObjectsWithContext(
-		ctx,
-		&s3.DeleteObjectsInput{
-			Bucket: aws.String(bucket),
-			Delete: &s3.Delete{
-				Objects: toDelete,       // Array of Keys to Delete
-				Quiet:   aws.Bool(true), // Only report errors.
-			},
-			BypassGovernanceRetention: aws.Bool(true), // Bypass Object Governance Configuration
+	for _, v := range page.DeleteMarkers {
+		toDelete = append(toDelete, &s3.ObjectIdentifier{
+			Key:       v.Key,
+			VersionId: v.VersionId,
 		})
-
-	if tfawserr.ErrCodeEquals(err, s3.ErrCodeNoSuchBucket) {
-		return nObjects, nil
-	}
-
-	if err != nil {
-		return nObjects, fmt.Errorf("deleting S3 Bucket (%s) objects: %w", bucket, err)
 	}
 
-	nObjects -= int64(len(output.Errors))
+	return toDelete
+}
 
-	for _, v := range output.Errors {
-		code := aws.StringValue(v.Code)
+// deleteBatchOfObjectVersions deletes a batch (<= 1000) of S3 object versions, optionally bypassing GOVERNANCE mode
+// retention. Versions that can't be deleted are reported, never unlocked. Every batch sent is recorded in the audit log.
+func deleteBatchOfObjectVersions(ctx context.Context, client *s3.S3, audit *AuditLog, bucket string, toDelete []*s3.ObjectIdentifier, bypassGovernance bool) (int64, error) {
 
-		if code == s3.ErrCodeNoSuchKey {
-			continue
-		}
+	var deniedErrs *multierror.Error
 
-		// Remove any objects with a hold configuration by removing object hold
-		if code == ErrCodeAccessDenied {
-			key := aws.StringValue(v.Key)
-			versionID := aws.StringValue(v.VersionId)
-
-			_, err := client.PutObjectLegalHoldWithContext(ctx, &s3.PutObjectLegalHoldInput{
-				Bucket:    aws.String(bucket),
-				Key:       aws.String(key),
-				VersionId: aws.String(versionID),
-				LegalHold: &s3.ObjectLockLegalHold{
-					Status: aws.String(s3.ObjectLockLegalHoldStatusOff),
-				},
-			})
+	nObjects, denied, err := deleteObjectVersions(ctx, client, audit, bucket, toDelete, bypassGovernance)
 
-			if err != nil {
-				// Add the original error and the new error.
-				deleteErrs = multierror.Append(deleteErrs, newDeleteObjectVersionError(v))
-				deleteErrs = multierror.Append(deleteErrs, fmt.Errorf("removing legal hold: %w", newObjectVersionError(key, versionID, err)))
-			} else {
-				// Attempt to delete the object once the legal hold has been removed.
-				_, err := client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
-					Bucket:    aws.String(bucket),
-					Key:       aws.String(key),
-					VersionId: aws.String(versionID),
-				})
-
-				if err != nil {
-					deleteErrs = multierror.Append(deleteErrs, fmt.Errorf("deleting: %w", newObjectVersionError(key, versionID, err)))
-				} else {
-					nObjects++
-				}
-			}
-		} else {
-			deleteErrs = multierror.Append(deleteErrs, newDeleteObjectVersionError(v))
-		}
+	if len(denied) == 0 {
+		return nObjects, err
 	}
 
-	if err := deleteErrs.ErrorOrNil(); err != nil {
-		return nObjects, fmt.Errorf("deleting S3 Bucket (%s) objects: %w", bucket, err)
+	for _, v := range denied {
+		deniedErrs = multierror.Append(deniedErrs, newDeleteObjectVersionError(v))
 	}
 
-	return nObjects, nil
+	return nObjects, multierror.Append(err, fmt.Errorf("deleting S3 Bucket (%s) objects: %w", bucket, deniedErrs))
 }
 
-// deletePageOfDeleteMarkers deletes a page (<= 1000) of S3 object delete markers.
-func deletePageOfDeleteMarkers(ctx context.Context, client *s3.S3, bucket string, page *s3.ListObjectVersionsOutput) (int64, error) {
+// deleteObjectVersions sends a single DeleteObjects batch. Versions refused w. AccessDenied (e.g. locked versions) are
+// returned to the caller, every other per-version error is returned as an error.
+func deleteObjectVersions(ctx context.Context, client *s3.S3, audit *AuditLog, bucket string, toDelete []*s3.ObjectIdentifier, bypassGovernance bool) (int64, []*s3.Error, error) {
 
 	var (
 		nObjects   int64
+		denied     []*s3.Error
 		deleteErrs *multierror.Error
 	)
 
-	toDelete := make([]*s3.ObjectIdentifier, 0, len(page.Versions))
-	for _, v := range page.DeleteMarkers {
-		toDelete = append(toDelete, &s3.ObjectIdentifier{
-			Key:       v.Key,
-			VersionId: v.VersionId,
-		})
-	}
-
 	if nObjects = int64(len(toDelete)); nObjects == 0 {
-		return nObjects, nil
+		return nObjects, nil, nil
 	}
 
 	input := &s3.DeleteObjectsInput{
 		Bucket: aws.String(bucket),
 		Delete: &s3.Delete{
-			Objects: toDelete,
+			Objects: toDelete,       // Array of Keys to Delete
 			Quiet:   aws.Bool(true), // Only report errors.
 		},
 	}
 
-	output, err := client.DeleteObjectsWithContext(ctx, input)
-
-	if tfawserr.ErrCodeEquals(err, s3.ErrCodeNoSuchBucket) {
-		return nObjects, nil
+	if bypassGovernance {
+		input.BypassGovernanceRetention = aws.Bool(true) // Bypass Object Governance Configuration
 	}
 
-	if err != nil {
-		return nObjects, fmt.Errorf("deleting S3 Bucket (%s) delete markers: %w", bucket, err)
-	}
+	// Delete Objects -
+	output, err := client.DeleteObjectsWithContext(ctx, input)
 
-	nObjects -= int64(len(output.Errors))
+	auditErr := audit.RecordDeleteObjects(bucket, toDelete, output, err)
+
+	switch {
+	case tfawserr.ErrCodeEquals(err, s3.ErrCodeNoSuchBucket):
+		err = nil
+	case err != nil:
+		nObjects, err = 0, fmt.Errorf("deleting S3 Bucket (%s) objects: %w", bucket, err)
+	default:
+		nObjects -= int64(len(output.Errors))
+
+		for _, v := range output.Errors {
+			switch aws.StringValue(v.Code) {
+			case s3.ErrCodeNoSuchKey:
+				continue
+			case ErrCodeAccessDenied:
+				denied = append(denied, v)
+			default:
+				deleteErrs = multierror.Append(deleteErrs, newDeleteObjectVersionError(v))
+			}
+		}
 
-	for _, v := range output.Errors {
-		deleteErrs = multierror.Append(deleteErrs, newDeleteObjectVersionError(v))
+		if deleteErrs != nil {
+			err = fmt.Errorf("deleting S3 Bucket (%s) objects: %w", bucket, deleteErrs)
+		}
 	}
 
-	if err := deleteErrs.ErrorOrNil(); err != nil {
-		return nObjects, fmt.Errorf("deleting S3 Bucket (%s) delete markers: %w", bucket, err)
+	// A failed audit write is reported alongside the batch's outcome, never in its place
+	if auditErr != nil {
+		return nObjects, denied, multierror.Append(err, auditErr)
 	}
 
-	return nObjects, nil
+	return nObjects, denied, err
 }
 
 // newObjectVersionError
@@ -271,6 +624,21 @@ func expandVersioning(l []interface{}) *s3.VersioningConfiguration {
 	return output
 }
 
+// flattenVersioning - Buckets that never had versioning configured report no status
+func flattenVersioning(output *s3.GetBucketVersioningOutput) []interface{} {
+
+	if output == nil {
+		return nil
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"enabled":    aws.StringValue(output.Status) == s3.BucketVersioningStatusEnabled,
+			"mfa_delete": aws.StringValue(output.MFADelete) == s3.MFADeleteStatusEnabled,
+		},
+	}
+}
+
 //
 func expandVersioningWhenIsNewResource(l []interface{}) *s3.VersioningConfiguration {
 
@@ -300,17 +668,24 @@ func expandVersioningWhenIsNewResource(l []interface{}) *s3.VersioningConfigurat
 	return output
 }
 
-// resourceBucketInternalVersioningUpdate
-func resourceBucketInternalVersioningUpdate(client *s3.S3, bucket string, versioningConfig *s3.VersioningConfiguration) error {
-	_, err := RetryWhenAWSErrCodeEquals(
-		2*time.Minute,
+// resourceBucketInternalVersioningUpdate - Returns once the new versioning configuration is
+// consistently visible
+func resourceBucketInternalVersioningUpdate(ctx context.Context, client *s3.S3, bucket string, versioningConfig *s3.VersioningConfiguration, timeout time.Duration) error {
+	_, err := RetryWhenAWSErrCodeEqualsContext(
+		ctx,
+		timeout,
 		func() (interface{}, error) {
-			return client.PutBucketVersioning(&s3.PutBucketVersioningInput{
+			return client.PutBucketVersioningWithContext(ctx, &s3.PutBucketVersioningInput{
 				Bucket:                  aws.String(bucket),
 				VersioningConfiguration: versioningConfig,
 			})
 		},
 		s3.ErrCodeNoSuchBucket,
 	)
-	return err
+
+	if err != nil {
+		return err
+	}
+
+	return waitForBucketVersioning(ctx, client, bucket, versioningConfig, timeout)
 }
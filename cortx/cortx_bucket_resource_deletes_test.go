@@ -0,0 +1,382 @@
+package cortx
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCheckLockedObjectVersions(t *testing.T) {
+
+	retainUntil := time.Now().Add(24 * time.Hour)
+
+	governance := &lockedObjectVersion{Key: "a", VersionID: "1", Mode: "GOVERNANCE", RetainUntil: retainUntil}
+	compliance := &lockedObjectVersion{Key: "b", VersionID: "2", Mode: "COMPLIANCE", RetainUntil: retainUntil}
+	legalHold := &lockedObjectVersion{Key: "c", VersionID: "3", LegalHold: true}
+
+	cases := []struct {
+		name         string
+		locked       []*lockedObjectVersion
+		lockHandling string
+		expectErr    bool
+	}{
+		{"no locks", nil, ObjectLockHandlingRespect, false},
+		{"respect governance", []*lockedObjectVersion{governance}, ObjectLockHandlingRespect, true},
+		{"bypass governance", []*lockedObjectVersion{governance}, ObjectLockHandlingBypassGovernance, false},
+		{"bypass governance legal hold", []*lockedObjectVersion{legalHold}, ObjectLockHandlingBypassGovernance, true},
+		{"remove legal holds", []*lockedObjectVersion{governance, legalHold}, ObjectLockHandlingRemoveLegalHolds, false},
+		{"compliance", []*lockedObjectVersion{compliance}, ObjectLockHandlingRemoveLegalHolds, true},
+	}
+
+	for _, tc := range cases {
+		err := checkLockedObjectVersions("test-bucket", tc.locked, tc.lockHandling)
+		if tc.expectErr != (err != nil) {
+			t.Errorf("%s: expected error: %t, got: %v", tc.name, tc.expectErr, err)
+		}
+	}
+
+	err := checkLockedObjectVersions("test-bucket", []*lockedObjectVersion{compliance}, ObjectLockHandlingRemoveLegalHolds)
+	if err == nil || !strings.Contains(err.Error(), "COMPLIANCE") || !strings.Contains(err.Error(), "b (version 2)") {
+		t.Errorf("expected COMPLIANCE version to be reported, got: %v", err)
+	}
+}
+
+func TestLockedObjectVersionBlockedBy(t *testing.T) {
+
+	retainUntil := time.Now().Add(24 * time.Hour)
+
+	cases := []struct {
+		name         string
+		version      *lockedObjectVersion
+		lockHandling string
+		expected     bool
+	}{
+		{"governance respect", &lockedObjectVersion{Mode: "GOVERNANCE", RetainUntil: retainUntil}, ObjectLockHandlingRespect, true},
+		{"governance bypass", &lockedObjectVersion{Mode: "GOVERNANCE", RetainUntil: retainUntil}, ObjectLockHandlingBypassGovernance, false},
+		{"legal hold bypass", &lockedObjectVersion{LegalHold: true}, ObjectLockHandlingBypassGovernance, true},
+		{"legal hold remove", &lockedObjectVersion{LegalHold: true}, ObjectLockHandlingRemoveLegalHolds, false},
+		{"compliance remove", &lockedObjectVersion{Mode: "COMPLIANCE", RetainUntil: retainUntil, LegalHold: true}, ObjectLockHandlingRemoveLegalHolds, true},
+	}
+
+	for _, tc := range cases {
+		if got := tc.version.BlockedBy(tc.lockHandling); got != tc.expected {
+			t.Errorf("%s: expected %t, got %t", tc.name, tc.expected, got)
+		}
+	}
+}
+
+// fakeS3Version - An object version (or delete marker) held by fakeS3
+type fakeS3Version struct {
+	Key          string
+	VersionID    string
+	DeleteMarker bool
+	Mode         string
+	RetainUntil  time.Time
+	LegalHold    bool
+
+	deleted bool
+}
+
+// fakeS3 - Serves the handful of S3 APIs used to empty and delete a single bucket, path-style.
+// Versions are listed `pageSize` at a time, DeleteObjects refuses the keys in `deleteErrors` w.
+// the given error code
+type fakeS3 struct {
+	mu           sync.Mutex
+	lockEnabled  bool
+	pageSize     int
+	versions     []*fakeS3Version
+	deleteErrors map[string]string
+	deleteCalls  int
+
+	// notEmpty - DeleteBucket reports BucketNotEmpty even when no versions are listed
+	notEmpty bool
+
+	// hidden - CreateBucket succeeds, but HeadBucket never finds the bucket
+	hidden bool
+}
+
+// newFakeS3Client - A client for `f`, served until the test ends
+func newFakeS3Client(t *testing.T, f *fakeS3) *s3.S3 {
+
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+
+	sess, err := session.NewSession(&aws.Config{
+		Endpoint:         aws.String(srv.URL),
+		Region:           aws.String("us-east-1"),
+		Credentials:      credentials.NewStaticCredentials("access", "secret", ""),
+		S3ForcePathStyle: aws.Bool(true),
+		DisableSSL:       aws.Bool(true),
+		MaxRetries:       aws.Int(0),
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return s3.New(sess)
+}
+
+// ServeHTTP -
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	query := r.URL.Query()
+	key := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2)
+
+	switch {
+	case r.Method == http.MethodGet && query.Has("object-lock"):
+		if !f.lockEnabled {
+			writeFakeS3Error(w, http.StatusNotFound, ErrCodeObjectLockConfigurationNotFound)
+			return
+		}
+		fmt.Fprint(w, `<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled></ObjectLockConfiguration>`)
+
+	case r.Method == http.MethodGet && query.Has("versioning"):
+		fmt.Fprint(w, `<VersioningConfiguration></VersioningConfiguration>`)
+
+	case r.Method == http.MethodGet && query.Has("tagging"):
+		writeFakeS3Error(w, http.StatusNotFound, ErrCodeNoSuchTagSet)
+
+	case r.Method == http.MethodGet && query.Has("versions"):
+		f.listVersions(w, query.Get("key-marker"))
+
+	case r.Method == http.MethodHead && len(key) == 2:
+		for _, v := range f.versions {
+			if !v.deleted && v.Key == key[1] && v.VersionID == query.Get("versionId") {
+				if v.Mode != "" {
+					w.Header().Set("x-amz-object-lock-mode", v.Mode)
+					w.Header().Set("x-amz-object-lock-retain-until-date", v.RetainUntil.UTC().Format(time.RFC3339))
+				}
+				if v.LegalHold {
+					w.Header().Set("x-amz-object-lock-legal-hold", s3.ObjectLockLegalHoldStatusOn)
+				}
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+
+	case r.Method == http.MethodPost && query.Has("delete"):
+		f.deleteObjects(w, r)
+
+	case r.Method == http.MethodPut && len(key) == 1 && len(query) == 0:
+		w.WriteHeader(http.StatusOK)
+
+	case r.Method == http.MethodHead && len(key) == 1:
+		if f.hidden {
+			w.WriteHeader(http.StatusNotFound)
+		}
+
+	case r.Method == http.MethodDelete && len(key) == 1:
+		if f.remaining() > 0 || f.notEmpty {
+			writeFakeS3Error(w, http.StatusConflict, ErrCodeBucketNotEmpty)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeFakeS3Error(w, http.StatusNotImplemented, ErrCodeNotImplemented)
+	}
+}
+
+// remaining - Number of versions not deleted
+func (f *fakeS3) remaining() int {
+
+	n := 0
+	for _, v := range f.versions {
+		if !v.deleted {
+			n++
+		}
+	}
+
+	return n
+}
+
+// listVersions - The key marker is the index of the first version of the page, deleted versions
+// keep their index s.t. deleting while listing doesn't shift pages
+func (f *fakeS3) listVersions(w http.ResponseWriter, marker string) {
+
+	start, _ := strconv.Atoi(marker)
+	end := len(f.versions)
+
+	if f.pageSize > 0 && start+f.pageSize < end {
+		end = start + f.pageSize
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, `<ListVersionsResult><IsTruncated>%t</IsTruncated>`, end < len(f.versions))
+	if end < len(f.versions) {
+		fmt.Fprintf(&b, `<NextKeyMarker>%d</NextKeyMarker><NextVersionIdMarker>%d</NextVersionIdMarker>`, end, end)
+	}
+
+	for _, v := range f.versions[start:end] {
+		if v.deleted {
+			continue
+		}
+
+		element := "Version"
+		if v.DeleteMarker {
+			element = "DeleteMarker"
+		}
+		fmt.Fprintf(&b, `<%s><Key>%s</Key><VersionId>%s</VersionId><IsLatest>true</IsLatest><Size>1</Size></%s>`, element, v.Key, v.VersionID, element)
+	}
+
+	b.WriteString(`</ListVersionsResult>`)
+	fmt.Fprint(w, b.String())
+}
+
+// deleteObjects -
+func (f *fakeS3) deleteObjects(w http.ResponseWriter, r *http.Request) {
+
+	f.deleteCalls++
+
+	var input struct {
+		Objects []struct {
+			Key       string `xml:"Key"`
+			VersionID string `xml:"VersionId"`
+		} `xml:"Object"`
+	}
+
+	if err := xml.NewDecoder(r.Body).Decode(&input); err != nil {
+		writeFakeS3Error(w, http.StatusBadRequest, "MalformedXML")
+		return
+	}
+
+	var b strings.Builder
+	b.WriteString(`<DeleteResult>`)
+
+	for _, o := range input.Objects {
+		if code, ok := f.deleteErrors[o.Key]; ok {
+			fmt.Fprintf(&b, `<Error><Key>%s</Key><VersionId>%s</VersionId><Code>%s</Code><Message>%s</Message></Error>`, o.Key, o.VersionID, code, code)
+			continue
+		}
+
+		for _, v := range f.versions {
+			if v.Key == o.Key && v.VersionID == o.VersionID {
+				v.deleted = true
+			}
+		}
+	}
+
+	b.WriteString(`</DeleteResult>`)
+	fmt.Fprint(w, b.String())
+}
+
+// writeFakeS3Error -
+func writeFakeS3Error(w http.ResponseWriter, status int, code string) {
+	w.WriteHeader(status)
+	fmt.Fprintf(w, `<Error><Code>%s</Code><Message>%s</Message></Error>`, code, code)
+}
+
+func TestEmptyBucketChecksLocksUpFront(t *testing.T) {
+
+	retainUntil := time.Now().Add(24 * time.Hour)
+
+	cases := []struct {
+		name         string
+		locked       *fakeS3Version
+		lockHandling string
+		expectErr    bool
+	}{
+		{"compliance", &fakeS3Version{Key: "locked", VersionID: "1", Mode: s3.ObjectLockModeCompliance, RetainUntil: retainUntil}, ObjectLockHandlingRemoveLegalHolds, true},
+		{"governance respected", &fakeS3Version{Key: "locked", VersionID: "1", Mode: s3.ObjectLockModeGovernance, RetainUntil: retainUntil}, ObjectLockHandlingRespect, true},
+		{"legal hold kept", &fakeS3Version{Key: "locked", VersionID: "1", LegalHold: true}, ObjectLockHandlingBypassGovernance, true},
+		{"governance bypassed", &fakeS3Version{Key: "locked", VersionID: "1", Mode: s3.ObjectLockModeGovernance, RetainUntil: retainUntil}, ObjectLockHandlingBypassGovernance, false},
+	}
+
+	for _, tc := range cases {
+		f := &fakeS3{
+			lockEnabled: true,
+			versions: []*fakeS3Version{
+				{Key: "a", VersionID: "1"},
+				tc.locked,
+				{Key: "b", VersionID: "2", DeleteMarker: true},
+			},
+		}
+
+		_, err := EmptyBucket(context.Background(), newFakeS3Client(t, f), nil, "bucket", tc.lockHandling)
+
+		if tc.expectErr != (err != nil) {
+			t.Errorf("%s: expected error: %t, got: %v", tc.name, tc.expectErr, err)
+		}
+
+		if tc.expectErr && (f.deleteCalls != 0 || f.remaining() != 3) {
+			t.Errorf("%s: expected nothing to be deleted, got %d DeleteObjects calls and %d versions left", tc.name, f.deleteCalls, f.remaining())
+		}
+
+		if !tc.expectErr && f.remaining() != 0 {
+			t.Errorf("%s: expected the bucket to be emptied, %d versions left", tc.name, f.remaining())
+		}
+	}
+}
+
+func TestDeleteAllObjectVersions(t *testing.T) {
+
+	f := &fakeS3{
+		pageSize:     3,
+		deleteErrors: map[string]string{"fail-1": "InternalError", "fail-2": "InternalError"},
+	}
+
+	for i := 0; i < 10; i++ {
+		f.versions = append(f.versions, &fakeS3Version{Key: fmt.Sprintf("key-%d", i), VersionID: "1", DeleteMarker: i%4 == 0})
+	}
+
+	f.versions = append(f.versions, &fakeS3Version{Key: "fail-1", VersionID: "1"}, &fakeS3Version{Key: "fail-2", VersionID: "2"})
+
+	n, err := deleteAllObjectVersions(context.Background(), newFakeS3Client(t, f), nil, "bucket", ObjectLockHandlingRespect)
+
+	if n != 10 {
+		t.Errorf("expected 10 deleted object versions, got %d", n)
+	}
+
+	if f.deleteCalls != 4 {
+		t.Errorf("expected a DeleteObjects batch per page (4), got %d", f.deleteCalls)
+	}
+
+	if err == nil || !strings.Contains(err.Error(), "fail-1") || !strings.Contains(err.Error(), "fail-2") {
+		t.Errorf("expected an error for each failed version, got: %v", err)
+	}
+
+	if f.remaining() != 2 {
+		t.Errorf("expected only the failed versions to be left, got %d", f.remaining())
+	}
+}
+
+func TestResourceBucketInternalDeleteStopsWithoutProgress(t *testing.T) {
+
+	f := &fakeS3{notEmpty: true}
+
+	d := schema.TestResourceDataRaw(t, resourceBucket().Schema, map[string]interface{}{
+		"bucket":        "bucket",
+		"force_destroy": true,
+	})
+	d.SetId("bucket")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	diags := resourceBucketInternalDelete(ctx, &CortxClient{S3: newFakeS3Client(t, f)}, d, nil)
+
+	if !diags.HasError() || ctx.Err() != nil {
+		t.Fatalf("expected an error before the deadline, got: %v", diags)
+	}
+
+	if !strings.Contains(diags[0].Detail, "no object versions to delete") {
+		t.Errorf("expected the no-progress error, got: %v", diags)
+	}
+}
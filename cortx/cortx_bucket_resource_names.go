@@ -0,0 +1,144 @@
+package cortx
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"net"
+	"regexp"
+	"strings"
+)
+
+//
+// NOTE: Bucket naming rules, checked at plan time s.t. an invalid name never reaches CreateBucket
+// See: https://docs.aws.amazon.com/AmazonS3/latest/userguide/bucketnamingrules.html
+//
+
+const (
+	bucketNameMinLength = 3
+	bucketNameMaxLength = 63
+)
+
+// bucketNameLabelRegexp - A single DNS label, lowercase letters, digits, and hyphens, beginning
+// and ending w. a letter or digit
+var bucketNameLabelRegexp = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+// validateBucketName - Lowercase, DNS compliant, 3-63 characters, no consecutive dots, and not
+// formatted as an IP address
+func validateBucketName(i interface{}, k string) (warnings []string, errors []error) {
+
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return warnings, errors
+	}
+
+	if err := checkBucketName(v); err != nil {
+		errors = append(errors, fmt.Errorf("%q %w", k, err))
+	}
+
+	return warnings, errors
+}
+
+// validateBucketPrefix - The prefix is valid when every name generated from it is, checked by
+// validating the prefix w. a placeholder of the same length as the generated suffix
+func validateBucketPrefix(i interface{}, k string) (warnings []string, errors []error) {
+
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return warnings, errors
+	}
+
+	if maxLength := bucketNameMaxLength - resource.UniqueIDSuffixLength; len(v) > maxLength {
+		errors = append(errors, fmt.Errorf("%q cannot be longer than %d characters, got: %s", k, maxLength, v))
+		return warnings, errors
+	}
+
+	if err := checkBucketName(v + strings.Repeat("0", resource.UniqueIDSuffixLength)); err != nil {
+		errors = append(errors, fmt.Errorf("%q would generate invalid bucket names, %w", k, err))
+	}
+
+	return warnings, errors
+}
+
+// checkBucketName -
+func checkBucketName(name string) error {
+
+	if len(name) < bucketNameMinLength || len(name) > bucketNameMaxLength {
+		return fmt.Errorf("must be between %d and %d characters, got: %s", bucketNameMinLength, bucketNameMaxLength, name)
+	}
+
+	if name != strings.ToLower(name) {
+		return fmt.Errorf("must be lowercase, got: %s", name)
+	}
+
+	if net.ParseIP(name) != nil {
+		return fmt.Errorf("must not be formatted as an IP address, got: %s", name)
+	}
+
+	if strings.Contains(name, "..") {
+		return fmt.Errorf("must not contain consecutive dots, got: %s", name)
+	}
+
+	for _, label := range strings.Split(name, ".") {
+		if !bucketNameLabelRegexp.MatchString(label) {
+			return fmt.Errorf(
+				"must be DNS compliant (lowercase letters, digits, hyphens, and dots, each dot separated label "+
+					"beginning and ending w. a letter or digit), got: %s", name,
+			)
+		}
+	}
+
+	return nil
+}
+
+// bucketName - The configured `bucket`, else a unique name generated from `bucket_prefix`, else a
+// unique `terraform-` name
+func bucketName(name, prefix string) string {
+
+	if name != "" {
+		return name
+	}
+
+	if prefix != "" {
+		return resource.PrefixedUniqueId(prefix)
+	}
+
+	return resource.UniqueId()
+}
+
+// bucketARN - Synthetic ARN using CORTX as provider Partition
+func bucketARN(bucket string) string {
+	return arn.ARN{
+		Partition: endpoints.AwsPartition().ID(),
+		Service:   "s3",
+		Resource:  bucket,
+	}.String()
+}
+
+// bucketNameFromImportID - Accepts a bucket name or a bucket ARN (`arn:aws:s3:::bucket`)
+func bucketNameFromImportID(id string) (string, error) {
+
+	name := id
+
+	if arn.IsARN(id) {
+		parsed, err := arn.Parse(id)
+		if err != nil {
+			return "", fmt.Errorf("parsing import ID (%s): %w", id, err)
+		}
+
+		if parsed.Service != "s3" || strings.Contains(parsed.Resource, "/") {
+			return "", fmt.Errorf("import ID (%s) is not an S3 bucket ARN", id)
+		}
+
+		name = parsed.Resource
+	}
+
+	if err := checkBucketName(name); err != nil {
+		return "", fmt.Errorf("import ID (%s) bucket name %w", id, err)
+	}
+
+	return name, nil
+}
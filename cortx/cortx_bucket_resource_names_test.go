@@ -0,0 +1,101 @@
+package cortx
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateBucketName(t *testing.T) {
+
+	cases := []struct {
+		name      string
+		expectErr bool
+	}{
+		{"my-bucket", false},
+		{"my.bucket.01", false},
+		{"abc", false},
+		{strings.Repeat("a", 63), false},
+		{"ab", true},
+		{strings.Repeat("a", 64), true},
+		{"My-Bucket", true},
+		{"my_bucket", true},
+		{"my..bucket", true},
+		{"-my-bucket", true},
+		{"my-bucket-", true},
+		{"my-.bucket", true},
+		{"192.168.5.4", true},
+	}
+
+	for _, c := range cases {
+		_, errs := validateBucketName(c.name, "bucket")
+		if got := len(errs) > 0; got != c.expectErr {
+			t.Errorf("%s: expected error %t, got %v", c.name, c.expectErr, errs)
+		}
+	}
+}
+
+func TestValidateBucketPrefix(t *testing.T) {
+
+	cases := []struct {
+		prefix    string
+		expectErr bool
+	}{
+		{"pr-1234-", false},
+		{"preview.", false},
+		{"a", false},
+		{strings.Repeat("a", 37), false},
+		{strings.Repeat("a", 38), true},
+		{"PR-1234-", true},
+		{"-preview", true},
+		{"preview-.", true},
+	}
+
+	for _, c := range cases {
+		_, errs := validateBucketPrefix(c.prefix, "bucket_prefix")
+		if got := len(errs) > 0; got != c.expectErr {
+			t.Errorf("%s: expected error %t, got %v", c.prefix, c.expectErr, errs)
+		}
+	}
+}
+
+func TestBucketName(t *testing.T) {
+
+	if got := bucketName("my-bucket", "ignored-"); got != "my-bucket" {
+		t.Errorf("expected configured name, got %s", got)
+	}
+
+	got := bucketName("", "pr-1234-")
+	if !strings.HasPrefix(got, "pr-1234-") || checkBucketName(got) != nil {
+		t.Errorf("expected a valid name w. the prefix, got %s", got)
+	}
+
+	if got == bucketName("", "pr-1234-") {
+		t.Errorf("expected unique names, got %s twice", got)
+	}
+
+	if got := bucketName("", ""); checkBucketName(got) != nil {
+		t.Errorf("expected a valid generated name, got %s", got)
+	}
+}
+
+func TestBucketNameFromImportID(t *testing.T) {
+
+	cases := []struct {
+		id        string
+		expected  string
+		expectErr bool
+	}{
+		{"my-bucket", "my-bucket", false},
+		{"arn:aws:s3:::my-bucket", "my-bucket", false},
+		{"arn:aws:s3:::my-bucket/key", "", true},
+		{"arn:aws:iam::123456789012:user/me", "", true},
+		{"My_Bucket", "", true},
+	}
+
+	for _, c := range cases {
+		got, err := bucketNameFromImportID(c.id)
+		if (err != nil) != c.expectErr || got != c.expected {
+			t.Errorf("%s: expected %q (error %t), got %q (%v)", c.id, c.expected, c.expectErr, got, err)
+		}
+	}
+}
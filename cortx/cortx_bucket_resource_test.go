@@ -0,0 +1,97 @@
+package cortx
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"testing"
+	"time"
+)
+
+func TestBucketRegionRoundTrip(t *testing.T) {
+
+	for _, region := range []string{"us-east-1", "us-west-2", "eu-west-1", "cortx-rack-1"} {
+		var constraint string
+
+		if config := expandBucketCreateConfiguration(region); config != nil {
+			constraint = aws.StringValue(config.LocationConstraint)
+		}
+
+		// GetBucketLocation reports the constraint the bucket was created w.
+		if got := s3.NormalizeBucketLocation(constraint); got != region {
+			t.Errorf("%s: expected to read back %s, got %s", region, region, got)
+		}
+	}
+
+	if expandBucketCreateConfiguration("") != nil {
+		t.Error("expected no location constraint w/o a region")
+	}
+}
+
+func TestBucketRegion(t *testing.T) {
+
+	cases := []struct {
+		name       string
+		current    string
+		fromServer string
+		expected   string
+	}{
+		{"imported", "", "eu-west-1", "eu-west-1"},
+		{"unchanged", "eu-west-1", "eu-west-1", "eu-west-1"},
+		{"constraint ignored by the server", "eu-west-1", "us-east-1", "eu-west-1"},
+	}
+
+	for _, tc := range cases {
+		if got := bucketRegion(tc.current, tc.fromServer); got != tc.expected {
+			t.Errorf("%s: expected %s, got %s", tc.name, tc.expected, got)
+		}
+	}
+}
+
+func TestResourcesDeclareTimeouts(t *testing.T) {
+
+	for name, r := range Provider().ResourcesMap {
+		if r.Timeouts == nil {
+			t.Errorf("%s: expected a timeouts block", name)
+			continue
+		}
+
+		timeouts := map[string]*time.Duration{
+			schema.TimeoutCreate: r.Timeouts.Create,
+			schema.TimeoutRead:   r.Timeouts.Read,
+			schema.TimeoutUpdate: r.Timeouts.Update,
+			schema.TimeoutDelete: r.Timeouts.Delete,
+		}
+
+		for k, v := range timeouts {
+			if v == nil || *v <= 0 {
+				t.Errorf("%s: expected a default %s timeout", name, k)
+			}
+		}
+	}
+}
+
+func TestResourceBucketCreateTimeout(t *testing.T) {
+
+	r := resourceBucket()
+	r.Timeouts.Create = schema.DefaultTimeout(2 * time.Second)
+
+	d := r.Data(nil)
+	d.Set("bucket", "bucket")
+
+	if got := d.Timeout(schema.TimeoutCreate); got != 2*time.Second {
+		t.Fatalf("expected the configured create timeout, got %s", got)
+	}
+
+	start := time.Now()
+	diags := resourceBucketCreate(context.Background(), d, &CortxClient{S3: newFakeS3Client(t, &fakeS3{hidden: true})})
+
+	if !diags.HasError() {
+		t.Fatal("expected waiting for a bucket that never becomes visible to fail")
+	}
+
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("expected the create timeout to bound the wait, took %s", elapsed)
+	}
+}
@@ -0,0 +1,252 @@
+package cortx
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// bucketUsageConcurrency - Number of prefixes walked in parallel
+const bucketUsageConcurrency = 4
+
+// bucketUsageTimeout - Bound on the whole walk, a large bucket lists every object version. The SDK
+// doesn't honour Timeouts on data sources, the read sets its own deadline
+const bucketUsageTimeout = 10 * time.Minute
+
+// datasourceBucketUsage
+//
+// Walks a bucket (or some of its prefixes) w. ListObjectVersions and reports what it holds. Each
+// prefix is walked separately, in parallel, and totals are the sum over prefixes. W/o prefixes the
+// top-level prefixes of the bucket are walked in parallel instead
+func datasourceBucketUsage() *schema.Resource {
+
+	return &schema.Resource{
+		ReadContext: datasourceBucketUsageRead,
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"prefixes": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"object_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"total_bytes": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"version_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"delete_marker_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"noncurrent_bytes": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"prefix_usage": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"prefix": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"object_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"total_bytes": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"version_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"delete_marker_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"noncurrent_bytes": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// datasourceBucketUsageRead -
+func datasourceBucketUsageRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	client := meta.(*CortxClient).S3
+	bucket := d.Get("bucket").(string)
+
+	// No prefixes - The whole bucket
+	prefixes := []string{""}
+	wholeBucket := true
+
+	if v := d.Get("prefixes").(*schema.Set); v.Len() > 0 {
+		wholeBucket = false
+		prefixes = make([]string, 0, v.Len())
+		for _, p := range v.List() {
+			prefixes = append(prefixes, p.(string))
+		}
+	}
+
+	sort.Strings(prefixes)
+
+	if err := checkOverlappingPrefixes(prefixes); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("[ERROR] Invalid prefixes (%s):", bucket),
+			Detail:   fmt.Sprintf("[ERROR] %v", err),
+		})
+		return diags
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, bucketUsageTimeout)
+	defer cancel()
+
+	// The whole bucket - Keys at the top level are counted while listing the top-level prefixes,
+	// which are then walked like given prefixes
+	walked := prefixes
+	root := &bucketUsage{}
+
+	if wholeBucket {
+		var err error
+
+		root, walked, err = measureBucketRootUsage(ctx, client, bucket)
+
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("[ERROR] Failed listing Bucket (%s) prefixes:", bucket),
+				Detail:   fmt.Sprintf("[ERROR] %v", err),
+			})
+			return diags
+		}
+	}
+
+	var (
+		wg       sync.WaitGroup
+		usages   = make([]*bucketUsage, len(walked))
+		errs     = make([]error, len(walked))
+		prefixCh = make(chan int, bucketUsageConcurrency)
+	)
+
+	for i := 0; i < bucketUsageConcurrency; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range prefixCh {
+				usages[i], errs[i] = measureBucketUsage(ctx, client, bucket, walked[i], 0)
+			}
+		}()
+	}
+
+	for i := range walked {
+		prefixCh <- i
+	}
+
+	close(prefixCh)
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("[ERROR] Failed measuring Bucket (%s) prefix (%s):", bucket, walked[i]),
+				Detail:   fmt.Sprintf("[ERROR] %v", err),
+			})
+		}
+	}
+
+	if diags.HasError() {
+		return diags
+	}
+
+	total := root
+	for _, u := range usages {
+		total.addUsage(u)
+	}
+
+	// Given prefixes are reported one by one, the whole bucket as a single "" prefix
+	prefixUsage := make([]interface{}, 0, len(prefixes))
+
+	if wholeBucket {
+		m := flattenBucketUsage(total)
+		m["prefix"] = ""
+		prefixUsage = append(prefixUsage, m)
+	} else {
+		for i, u := range usages {
+			m := flattenBucketUsage(u)
+			m["prefix"] = prefixes[i]
+			prefixUsage = append(prefixUsage, m)
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", bucket, strings.Join(prefixes, ",")))
+
+	for k, v := range flattenBucketUsage(total) {
+		d.Set(k, v)
+	}
+
+	if err := d.Set("prefix_usage", prefixUsage); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("[ERROR] Failed setting prefix_usage (%s):", d.Id()),
+			Detail:   fmt.Sprintf("[ERROR] %v", err),
+		})
+		return diags
+	}
+
+	return diags
+}
+
+// checkOverlappingPrefixes - A prefix nested in another would be counted twice, expects sorted
+// prefixes (a parent always sorts before the prefixes nested in it)
+func checkOverlappingPrefixes(prefixes []string) error {
+
+	for i := 1; i < len(prefixes); i++ {
+		for j := 0; j < i; j++ {
+			if strings.HasPrefix(prefixes[i], prefixes[j]) {
+				return fmt.Errorf("prefix (%s) overlaps prefix (%s), its objects would be counted twice", prefixes[i], prefixes[j])
+			}
+		}
+	}
+
+	return nil
+}
+
+// flattenBucketUsage -
+func flattenBucketUsage(u *bucketUsage) map[string]interface{} {
+	return map[string]interface{}{
+		"object_count":        int(u.Objects),
+		"total_bytes":         int(u.Bytes),
+		"version_count":       int(u.Versions),
+		"delete_marker_count": int(u.DeleteMarkers),
+		"noncurrent_bytes":    int(u.NoncurrentBytes),
+	}
+}
@@ -0,0 +1,40 @@
+package cortx
+
+import (
+	"testing"
+)
+
+func TestCheckOverlappingPrefixes(t *testing.T) {
+
+	cases := []struct {
+		prefixes  []string
+		expectErr bool
+	}{
+		{[]string{""}, false},
+		{[]string{"logs/", "media/"}, false},
+		{[]string{"logs/", "logs/2022/"}, true},
+		{[]string{"a/", "b/", "b/c/"}, true},
+	}
+
+	for _, c := range cases {
+		if err := checkOverlappingPrefixes(c.prefixes); (err != nil) != c.expectErr {
+			t.Errorf("%v: expected error %t, got %v", c.prefixes, c.expectErr, err)
+		}
+	}
+}
+
+func TestBucketUsageAddUsage(t *testing.T) {
+
+	total := &bucketUsage{Objects: 1, Versions: 2, Bytes: 10, NoncurrentBytes: 4}
+	total.addUsage(&bucketUsage{Objects: 3, Versions: 5, DeleteMarkers: 1, Bytes: 20, NoncurrentBytes: 6})
+
+	expected := bucketUsage{Objects: 4, Versions: 7, DeleteMarkers: 1, Bytes: 30, NoncurrentBytes: 10}
+	if *total != expected {
+		t.Errorf("expected %+v, got %+v", expected, *total)
+	}
+
+	total.addUsage(&bucketUsage{Truncated: true})
+	if !total.Truncated {
+		t.Error("expected a truncated part to truncate the total")
+	}
+}
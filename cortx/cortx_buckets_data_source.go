@@ -0,0 +1,350 @@
+package cortx
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/aws-sdk-go-base/v2/awsv1shim/v2/tfawserr"
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// bucketVersioningStatusDisabled - Versioning status filter value matching buckets that never had
+// versioning configured
+const bucketVersioningStatusDisabled = "Disabled"
+
+// bucketFilterConcurrency - Number of buckets looked up in parallel when filtering by tags or
+// versioning status
+const bucketFilterConcurrency = 8
+
+// datasourceBuckets
+//
+// Lists the caller's buckets, optionally filtered by name, and by tags or versioning status (which
+// takes a lookup per bucket)
+func datasourceBuckets() *schema.Resource {
+
+	return &schema.Resource{
+		ReadContext: datasourceBucketsRead,
+		Schema: map[string]*schema.Schema{
+			"name_prefix": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"tags": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"versioning_status": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					s3.BucketVersioningStatusEnabled,
+					s3.BucketVersioningStatusSuspended,
+					bucketVersioningStatusDisabled,
+				}, false),
+			},
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"buckets": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"arn": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"creation_date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// datasourceBucketsRead -
+func datasourceBucketsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	conn := meta.(*CortxClient)
+
+	output, err := conn.S3.ListBucketsWithContext(ctx, &s3.ListBucketsInput{})
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "[ERROR] Failed on ListBuckets:",
+			Detail:   fmt.Sprintf("[ERROR] %v", err),
+		})
+		return diags
+	}
+
+	var nameRegex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(v.(string))
+	}
+
+	prefix := d.Get("name_prefix").(string)
+	buckets := filterBucketsByName(output.Buckets, prefix, nameRegex)
+
+	tags := d.Get("tags").(map[string]interface{})
+	versioningStatus := d.Get("versioning_status").(string)
+
+	if len(tags) > 0 || versioningStatus != "" {
+		var skipped []string
+
+		buckets, skipped, err = filterBuckets(ctx, conn.S3, buckets, tags, versioningStatus)
+
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "[ERROR] Failed filtering buckets:",
+				Detail:   fmt.Sprintf("[ERROR] %v", err),
+			})
+			return diags
+		}
+
+		if len(skipped) > 0 {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "[WARN] Skipped buckets that couldn't be filtered:",
+				Detail: fmt.Sprintf(
+					"[WARN] The tags or versioning of %d buckets can't be read (access denied, or not supported by the server), "+
+						"they're left out of the results: %s", len(skipped), strings.Join(skipped, ", "),
+				),
+			})
+		}
+	}
+
+	names := make([]string, 0, len(buckets))
+	for _, b := range buckets {
+		names = append(names, aws.StringValue(b.Name))
+	}
+
+	d.SetId(bucketsFilterID(conn.Endpoint, prefix, d.Get("name_regex").(string), tags, versioningStatus))
+	d.Set("names", names)
+
+	if err := d.Set("buckets", flattenBuckets(buckets)); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "[ERROR] Failed setting buckets:",
+			Detail:   fmt.Sprintf("[ERROR] %v", err),
+		})
+		return diags
+	}
+
+	return diags
+}
+
+// filterBucketsByName - Keeps the buckets whose name starts w. `prefix` and (when set) matches
+// `nameRegex`
+func filterBucketsByName(buckets []*s3.Bucket, prefix string, nameRegex *regexp.Regexp) []*s3.Bucket {
+
+	filtered := make([]*s3.Bucket, 0, len(buckets))
+
+	for _, b := range buckets {
+		name := aws.StringValue(b.Name)
+
+		if !strings.HasPrefix(name, prefix) || (nameRegex != nil && !nameRegex.MatchString(name)) {
+			continue
+		}
+
+		filtered = append(filtered, b)
+	}
+
+	return filtered
+}
+
+// filterBuckets - Looks up every bucket concurrently, keeping those w. every one of `tags` and
+// (when set) the given versioning status. Buckets deleted since ListBuckets are dropped, buckets
+// whose tags or versioning can't be read (AccessDenied, or not supported by the server) are
+// dropped and returned as skipped. The original (alphabetical) order is kept
+func filterBuckets(ctx context.Context, client *s3.S3, buckets []*s3.Bucket, tags map[string]interface{}, versioningStatus string) ([]*s3.Bucket, []string, error) {
+
+	var (
+		mu        sync.Mutex
+		lookupErr *multierror.Error
+		wg        sync.WaitGroup
+	)
+
+	keep := make([]bool, len(buckets))
+	skip := make([]bool, len(buckets))
+	indexes := make(chan int, bucketFilterConcurrency)
+
+	for i := 0; i < bucketFilterConcurrency; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				ok, err := bucketMatches(ctx, client, aws.StringValue(buckets[i].Name), tags, versioningStatus)
+
+				mu.Lock()
+				switch {
+				case tfawserr.ErrCodeEquals(err, ErrCodeAccessDenied) || NotSupportedByServer(err):
+					skip[i] = true
+				case err != nil:
+					lookupErr = multierror.Append(lookupErr, err)
+				}
+				keep[i] = ok
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i := range buckets {
+		indexes <- i
+	}
+
+	close(indexes)
+	wg.Wait()
+
+	if err := lookupErr.ErrorOrNil(); err != nil {
+		return nil, nil, err
+	}
+
+	var skipped []string
+
+	filtered := make([]*s3.Bucket, 0, len(buckets))
+	for i, b := range buckets {
+		switch {
+		case skip[i]:
+			skipped = append(skipped, aws.StringValue(b.Name))
+		case keep[i]:
+			filtered = append(filtered, b)
+		}
+	}
+
+	return filtered, skipped, nil
+}
+
+// bucketMatches -
+func bucketMatches(ctx context.Context, client *s3.S3, bucket string, tags map[string]interface{}, versioningStatus string) (bool, error) {
+
+	if versioningStatus != "" {
+		output, err := client.GetBucketVersioningWithContext(ctx, &s3.GetBucketVersioningInput{
+			Bucket: aws.String(bucket),
+		})
+
+		if tfawserr.ErrCodeEquals(err, s3.ErrCodeNoSuchBucket) {
+			return false, nil
+		}
+
+		if err != nil {
+			return false, fmt.Errorf("getting S3 Bucket (%s) versioning: %w", bucket, err)
+		}
+
+		if !bucketVersioningStatusMatches(output, versioningStatus) {
+			return false, nil
+		}
+	}
+
+	if len(tags) > 0 {
+		output, err := client.GetBucketTaggingWithContext(ctx, &s3.GetBucketTaggingInput{
+			Bucket: aws.String(bucket),
+		})
+
+		if tfawserr.ErrCodeEquals(err, s3.ErrCodeNoSuchBucket, ErrCodeNoSuchTagSet) {
+			return false, nil
+		}
+
+		if err != nil {
+			return false, fmt.Errorf("getting S3 Bucket (%s) tags: %w", bucket, err)
+		}
+
+		if !bucketTagsMatch(output.TagSet, tags) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// bucketVersioningStatusMatches - Buckets that never had versioning configured report no status,
+// matched by `Disabled`
+func bucketVersioningStatusMatches(output *s3.GetBucketVersioningOutput, versioningStatus string) bool {
+
+	status := aws.StringValue(output.Status)
+	if status == "" {
+		status = bucketVersioningStatusDisabled
+	}
+
+	return status == versioningStatus
+}
+
+// bucketTagsMatch - Reports whether the tag set carries every one of `tags`
+func bucketTagsMatch(tagSet []*s3.Tag, tags map[string]interface{}) bool {
+
+	bucketTags := make(map[string]string, len(tagSet))
+	for _, t := range tagSet {
+		bucketTags[aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+	}
+
+	for k, v := range tags {
+		if value, ok := bucketTags[k]; !ok || value != v.(string) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// bucketsFilterID - Data sources w. different filters against the same endpoint get different IDs
+func bucketsFilterID(endpoint, prefix, nameRegex string, tags map[string]interface{}, versioningStatus string) string {
+
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n%s\n%s\n", prefix, nameRegex, versioningStatus)
+	for _, k := range keys {
+		fmt.Fprintf(&b, "%s=%v\n", k, tags[k])
+	}
+
+	return fmt.Sprintf("%s/%d", endpoint, schema.HashString(b.String()))
+}
+
+// flattenBuckets -
+func flattenBuckets(buckets []*s3.Bucket) []interface{} {
+
+	l := make([]interface{}, 0, len(buckets))
+
+	for _, b := range buckets {
+		l = append(l, map[string]interface{}{
+			"name":          aws.StringValue(b.Name),
+			"arn":           bucketARN(aws.StringValue(b.Name)),
+			"creation_date": aws.TimeValue(b.CreationDate).Format(time.RFC3339),
+		})
+	}
+
+	return l
+}
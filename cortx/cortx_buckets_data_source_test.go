@@ -0,0 +1,119 @@
+package cortx
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestFilterBucketsByName(t *testing.T) {
+
+	buckets := []*s3.Bucket{
+		{Name: aws.String("team-a-logs")},
+		{Name: aws.String("team-a-data")},
+		{Name: aws.String("team-b-logs")},
+		{Name: aws.String("scratch")},
+	}
+
+	cases := []struct {
+		name      string
+		prefix    string
+		nameRegex string
+		expected  []string
+	}{
+		{"no filters", "", "", []string{"team-a-logs", "team-a-data", "team-b-logs", "scratch"}},
+		{"prefix", "team-a-", "", []string{"team-a-logs", "team-a-data"}},
+		{"regex", "", "-logs$", []string{"team-a-logs", "team-b-logs"}},
+		{"prefix and regex", "team-a-", "-logs$", []string{"team-a-logs"}},
+		{"no match", "other-", "", []string{}},
+	}
+
+	for _, tc := range cases {
+		var nameRegex *regexp.Regexp
+		if tc.nameRegex != "" {
+			nameRegex = regexp.MustCompile(tc.nameRegex)
+		}
+
+		names := []string{}
+		for _, b := range filterBucketsByName(buckets, tc.prefix, nameRegex) {
+			names = append(names, aws.StringValue(b.Name))
+		}
+
+		if !reflect.DeepEqual(names, tc.expected) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, names)
+		}
+	}
+}
+
+func TestBucketVersioningStatusMatches(t *testing.T) {
+
+	cases := []struct {
+		name     string
+		status   *string
+		filter   string
+		expected bool
+	}{
+		{"enabled", aws.String(s3.BucketVersioningStatusEnabled), s3.BucketVersioningStatusEnabled, true},
+		{"suspended", aws.String(s3.BucketVersioningStatusSuspended), s3.BucketVersioningStatusEnabled, false},
+		{"never configured is disabled", nil, bucketVersioningStatusDisabled, true},
+		{"never configured isn't suspended", nil, s3.BucketVersioningStatusSuspended, false},
+		{"enabled isn't disabled", aws.String(s3.BucketVersioningStatusEnabled), bucketVersioningStatusDisabled, false},
+	}
+
+	for _, tc := range cases {
+		output := &s3.GetBucketVersioningOutput{Status: tc.status}
+		if got := bucketVersioningStatusMatches(output, tc.filter); got != tc.expected {
+			t.Errorf("%s: expected %t, got %t", tc.name, tc.expected, got)
+		}
+	}
+}
+
+func TestBucketTagsMatch(t *testing.T) {
+
+	tagSet := []*s3.Tag{
+		{Key: aws.String("team"), Value: aws.String("a")},
+		{Key: aws.String("env"), Value: aws.String("prod")},
+	}
+
+	cases := []struct {
+		name     string
+		tags     map[string]interface{}
+		expected bool
+	}{
+		{"subset", map[string]interface{}{"team": "a"}, true},
+		{"all", map[string]interface{}{"team": "a", "env": "prod"}, true},
+		{"wrong value", map[string]interface{}{"team": "b"}, false},
+		{"missing key", map[string]interface{}{"owner": "a"}, false},
+	}
+
+	for _, tc := range cases {
+		if got := bucketTagsMatch(tagSet, tc.tags); got != tc.expected {
+			t.Errorf("%s: expected %t, got %t", tc.name, tc.expected, got)
+		}
+	}
+
+	if bucketTagsMatch(nil, map[string]interface{}{"team": "a"}) {
+		t.Error("expected a bucket w/o tags not to match")
+	}
+}
+
+func TestBucketsFilterID(t *testing.T) {
+
+	tags := map[string]interface{}{"team": "a", "env": "prod"}
+
+	id := bucketsFilterID("http://cortx:80", "team-", "", tags, "")
+
+	if id != bucketsFilterID("http://cortx:80", "team-", "", map[string]interface{}{"env": "prod", "team": "a"}, "") {
+		t.Error("expected the same filters to give the same ID")
+	}
+
+	if id == bucketsFilterID("http://cortx:80", "other-", "", tags, "") {
+		t.Error("expected different filters to give different IDs")
+	}
+
+	if id == bucketsFilterID("http://cortx:80", "team-", "", tags, bucketVersioningStatusDisabled) {
+		t.Error("expected different versioning filters to give different IDs")
+	}
+}
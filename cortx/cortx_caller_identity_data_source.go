@@ -0,0 +1,196 @@
+package cortx
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"strings"
+)
+
+// datasourceCallerIdentity
+//
+// Describes the identity the provider authenticates as. The canonical user ID and owner display
+// name come from the ListBuckets owner, which every S3 user may call. The IAM user name, ID, ARN
+// and account ID need an IAM GetUser call, which CORTX serves from a separate IAM endpoint
+// (`iam_endpoint`); when it's unset those attributes are left empty. W. `iam_endpoint` set, failing
+// to determine the account ID is an error
+func datasourceCallerIdentity() *schema.Resource {
+
+	return &schema.Resource{
+		ReadContext: datasourceCallerIdentityRead,
+		Schema: map[string]*schema.Schema{
+			"iam_endpoint": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"endpoint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"region": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"access_key_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"canonical_user_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"display_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"user_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"user_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"account_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// datasourceCallerIdentityRead -
+func datasourceCallerIdentityRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	conn := meta.(*CortxClient)
+
+	creds, err := conn.S3.Config.Credentials.GetWithContext(ctx)
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("[ERROR] Failed getting credentials (%s):", conn.Endpoint),
+			Detail:   fmt.Sprintf("[ERROR] %v", err),
+		})
+		return diags
+	}
+
+	output, err := conn.S3.ListBucketsWithContext(ctx, &s3.ListBucketsInput{})
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("[ERROR] Failed on ListBuckets (%s):", conn.Endpoint),
+			Detail:   fmt.Sprintf("[ERROR] %v", err),
+		})
+		return diags
+	}
+
+	if output.Owner == nil || aws.StringValue(output.Owner.ID) == "" {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("[ERROR] Failed on ListBuckets (%s):", conn.Endpoint),
+			Detail:   "[ERROR] Server returned no bucket owner",
+		})
+		return diags
+	}
+
+	canonicalID := aws.StringValue(output.Owner.ID)
+	displayName := aws.StringValue(output.Owner.DisplayName)
+
+	var userName, userID, userARN, accountID string
+
+	if v, ok := d.GetOk("iam_endpoint"); ok {
+
+		user, err := findIAMUser(ctx, conn, v.(string))
+
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("[ERROR] Failed on IAM GetUser (%s):", v.(string)),
+				Detail:   fmt.Sprintf("[ERROR] %v", err),
+			})
+			return diags
+		}
+
+		userName = aws.StringValue(user.UserName)
+		userID = aws.StringValue(user.UserId)
+		userARN = aws.StringValue(user.Arn)
+		accountID = accountIDFromARN(userARN)
+
+		if accountID == "" {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("[ERROR] Failed on IAM GetUser (%s):", v.(string)),
+				Detail:   fmt.Sprintf("[ERROR] Server returned no account ID, user ARN (%s) isn't an ARN", userARN),
+			})
+			return diags
+		}
+	}
+
+	d.SetId(canonicalID)
+	d.Set("endpoint", conn.Endpoint)
+	d.Set("region", aws.StringValue(conn.S3.Config.Region))
+	d.Set("access_key_id", creds.AccessKeyID)
+	d.Set("canonical_user_id", canonicalID)
+	d.Set("display_name", displayName)
+	d.Set("user_name", userName)
+	d.Set("user_id", userID)
+	d.Set("arn", userARN)
+	d.Set("account_id", accountID)
+
+	return diags
+}
+
+// findIAMUser - GetUser for the caller against the IAM endpoint, w. the same credentials, region
+// and TLS settings as the provider's S3 client
+func findIAMUser(ctx context.Context, conn *CortxClient, endpoint string) (*iam.User, error) {
+
+	cfg := conn.S3.Config.Copy()
+	cfg.Endpoint = aws.String(endpoint)
+	cfg.DisableSSL = aws.Bool(strings.HasPrefix(endpoint, "http://"))
+
+	sess, err := session.NewSession(cfg)
+
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := iam.New(sess).GetUserWithContext(ctx, &iam.GetUserInput{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if output.User == nil {
+		return nil, fmt.Errorf("server returned no user")
+	}
+
+	return output.User, nil
+}
+
+// accountIDFromARN - Account ID of an IAM ARN, empty when it's not an ARN
+func accountIDFromARN(s string) string {
+
+	parsed, err := arn.Parse(s)
+
+	if err != nil {
+		return ""
+	}
+
+	return parsed.AccountID
+}
@@ -0,0 +1,21 @@
+package cortx
+
+import (
+	"testing"
+)
+
+func TestAccountIDFromARN(t *testing.T) {
+
+	cases := map[string]string{
+		"arn:aws:iam::123456789012:user/alice": "123456789012",
+		"arn:aws:iam::123456789012:root":       "123456789012",
+		"":                                     "",
+		"alice":                                "",
+	}
+
+	for in, want := range cases {
+		if got := accountIDFromARN(in); got != want {
+			t.Errorf("accountIDFromARN(%q): expected %q, got %q", in, want, got)
+		}
+	}
+}
@@ -2,13 +2,21 @@ package cortx
 
 import (
 	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"io"
+	"log"
+	"mime"
 	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 // datasourceObject
@@ -21,6 +29,16 @@ func datasourceObject() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"body_base64": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"max_body_bytes": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
 			"bucket": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -123,7 +141,7 @@ func datasourceObject() *schema.Resource {
 func datasourceObjectRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	var diags diag.Diagnostics
-	client := meta.(*s3.S3)
+	client := meta.(*CortxClient).S3
 
 	// Bucket
 	bucket := d.Get("bucket").(string)
@@ -144,14 +162,14 @@ func datasourceObjectRead(ctx context.Context, d *schema.ResourceData, meta inte
 		input.VersionId = aws.String(v.(string))
 	}
 
-	out, err := client.HeadObject(&input)
+	out, err := client.HeadObjectWithContext(ctx, &input)
 
 	// Failed on GetHead Object
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  fmt.Sprintf("[ERROR] Failed getting Bucket (%s) Object (%s)", bucket, key),
-			Detail:   fmt.Sprintf("[ERROR] %w", err),
+			Detail:   fmt.Sprintf("[ERROR] %v", err),
 		})
 		return diags
 	}
@@ -160,15 +178,14 @@ func datasourceObjectRead(ctx context.Context, d *schema.ResourceData, meta inte
 	if aws.BoolValue(out.DeleteMarker) {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
-			Summary:  fmt.Sprintf("[ERROR] Requested Object (%s%s%s) has been deleted", bucket, key, input.VersionId),
-			Detail:   fmt.Sprintf("[ERROR] %w", err),
+			Summary:  fmt.Sprintf("[ERROR] Requested Object (%s) has been deleted", objectID(bucket, key, aws.StringValue(input.VersionId))),
+			Detail:   "[ERROR] The requested version is a delete marker",
 		})
 		return diags
 	}
 
-	// Set All Reader Params - Some removed to enforce compatbility with the
-	// CORTX API Implementation
-	d.SetId(fmt.Sprintf("%s/%s%@s", bucket, key, input.VersionId))
+	// Set All Reader Params - Fields CORTX doesn't return are left empty
+	d.SetId(objectID(bucket, key, aws.StringValue(out.VersionId)))
 	d.Set("bucket_key_enabled", out.BucketKeyEnabled)
 	d.Set("cache_control", out.CacheControl)
 	d.Set("content_disposition", out.ContentDisposition)
@@ -179,25 +196,25 @@ func datasourceObjectRead(ctx context.Context, d *schema.ResourceData, meta inte
 	d.Set("etag", strings.Trim(aws.StringValue(out.ETag), `"`))
 	d.Set("metadata", PointersMapToStringList(out.Metadata))
 
-	// Removed Expiration - Concept doesn't make sense in the context of CORTX
-	//
-	// d.Set("expiration", out.Expiration)
-	// d.Set("expires", out.Expires)
+	// Expiration - Set by lifecycle rules (`x-amz-expiration`) and the `Expires` header
+	d.Set("expiration", out.Expiration)
+	d.Set("expires", out.Expires)
 
-	// Removed Legal Hold - Concept doesn't make sense in the context of CORTX
-	//
-	// d.Set("object_lock_legal_hold_status", out.ObjectLockLegalHoldStatus)
-	// d.Set("object_lock_mode", out.ObjectLockMode)
-	// d.Set("object_lock_retain_until_date", flattenObjectDate(out.ObjectLockRetainUntilDate))
+	// Object Lock
+	d.Set("object_lock_legal_hold_status", out.ObjectLockLegalHoldStatus)
+	d.Set("object_lock_mode", out.ObjectLockMode)
+	d.Set("object_lock_retain_until_date", flattenObjectDate(out.ObjectLockRetainUntilDate))
 
-	// Removed SSE - Concept doesn't make sense in the context of CORTX
-	//
-	// d.Set("server_side_encryption", out.ServerSideEncryption)
-	// d.Set("sse_kms_key_id", out.SSEKMSKeyId)
+	// SSE
+	d.Set("server_side_encryption", out.ServerSideEncryption)
+	d.Set("sse_kms_key_id", out.SSEKMSKeyId)
 
-	// Removed Storage Class - Concept doesn't make sense in the context of CORTX
-	//
-	// d.Set("storage_class", s3.StorageClassStandard)
+	// Storage Class - Omitted from the response for STANDARD objects
+	if out.StorageClass != nil {
+		d.Set("storage_class", out.StorageClass)
+	} else {
+		d.Set("storage_class", s3.StorageClassStandard)
+	}
 
 	d.Set("version_id", out.VersionId)
 	d.Set("website_redirect_location", out.WebsiteRedirectLocation)
@@ -208,30 +225,191 @@ func datasourceObjectRead(ctx context.Context, d *schema.ResourceData, meta inte
 		d.Set("last_modified", out.LastModified.Format(time.RFC1123))
 	}
 
-	// Remove Concept of `Body` -> This is kinda an Anti-Pattern in the original provider
-	// why keep body for large files, => assumes user won't upload large files, need to be
-	// able to make that assumption w. CORTX
+	// Body - Opt-in w. `max_body_bytes`, keeping large objects out of state is left to the user
+	if maxBytes := int64(d.Get("max_body_bytes").(int)); maxBytes > 0 {
+		diags = append(diags, datasourceObjectInternalBodyRead(ctx, client, d, &input, out, maxBytes)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	// Tags - Of the version that was read
+	tagging, err := client.GetObjectTaggingWithContext(ctx, &s3.GetObjectTaggingInput{
+		Bucket:    aws.String(bucket),
+		Key:       aws.String(key),
+		VersionId: out.VersionId,
+	})
+
+	if NotSupportedByServer(err) {
+		log.Printf("[WARN] Unable to read tags of Bucket (%s) Object (%s): %v", bucket, key, err)
+		return diags
+	}
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("[ERROR] Failed listing tags for S3 Bucket (%s) Object (%s)", bucket, key),
+			Detail:   fmt.Sprintf("[ERROR] %v", err),
+		})
+		return diags
+	}
+
+	tags := make(map[string]*string, len(tagging.TagSet))
+	for _, t := range tagging.TagSet {
+		tags[aws.StringValue(t.Key)] = t.Value
+	}
+
+	if err := d.Set("tags", PointersMapToStringList(tags)); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("[ERROR] Failed setting tags (%s)", d.Id()),
+			Detail:   fmt.Sprintf("[ERROR] %v", err),
+		})
+		return diags
+	}
+
+	return diags
+}
+
+// datasourceObjectInternalBodyRead - Reads the (ranged) object w. GetObject, the version HeadObject
+// resolved is read s.t. body and metadata always match. Text is set as `body`, anything else (or
+// text that isn't valid UTF-8) as `body_base64`
+func datasourceObjectInternalBodyRead(ctx context.Context, client *s3.S3, d *schema.ResourceData, head *s3.HeadObjectInput, out *s3.HeadObjectOutput, maxBytes int64) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	output, err := client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket:    head.Bucket,
+		Key:       head.Key,
+		Range:     head.Range,
+		VersionId: out.VersionId,
+	})
+
+	if err != nil {
+		return append(diags, OperationErrorDiagnostic("GetObject", d.Id(), err))
+	}
+
+	defer output.Body.Close()
+
+	// Read one byte past the limit to tell "exactly max" from "too large"
+	body, err := io.ReadAll(io.LimitReader(output.Body, maxBytes+1))
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("[ERROR] Failed reading body of Object (%s):", d.Id()),
+			Detail:   fmt.Sprintf("[ERROR] %v", err),
+		})
+		return diags
+	}
 
-	// TODO: This is a valid feature - want to implement tag mnagement
-	// tags, err := ObjectListTags(client, bucket, key)
+	if int64(len(body)) > maxBytes {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("[WARN] Body of Object (%s) not read:", d.Id()),
+			Detail: fmt.Sprintf(
+				"[WARN] The object is larger than max_body_bytes (%d), increase max_body_bytes or set a range", maxBytes,
+			),
+		})
+		return diags
+	}
 
-	// if err != nil {
-	// 	diags = append(diags, diag.Diagnostic{
-	// 		Severity: diag.Error,
-	// 		Summary:  fmt.Sprintf("[ERROR] Failed listing tags for S3 Bucket (%s) Object (%s)", bucket, key),
-	// 		Detail:   fmt.Sprintf("[ERROR] %w", err),
-	// 	})
-	// 	return diags
-	// }
+	// A ranged read is only part of the object, the ETag covers the whole
+	if head.Range == nil {
+		if err := verifyObjectETag(body, output); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("[ERROR] Failed verifying body of Object (%s):", d.Id()),
+				Detail:   fmt.Sprintf("[ERROR] %v", err),
+			})
+			return diags
+		}
+	}
 
-	// if err := d.Set("tags", tags.IgnoreAWS().IgnoreConfig(ignoreTagsConfig).Map()); err != nil {
-	// 	diags = append(diags, diag.Diagnostic{
-	// 		Severity: diag.Error,
-	// 		Summary:  fmt.Sprintf("[ERROR] Failed setting tags"),
-	// 		Detail:   fmt.Sprintf("[ERROR] %w", err),
-	// 	})
-	// 	return diags
-	// }
+	text, encoded := flattenObjectBody(aws.StringValue(output.ContentType), body)
+	d.Set("body", text)
+	d.Set("body_base64", encoded)
 
 	return diags
 }
+
+// flattenObjectBody - Returns the body as text, or base64 encoded when it's not text (or text that
+// isn't valid UTF-8). The other is empty
+func flattenObjectBody(contentType string, body []byte) (string, string) {
+
+	if isTextContentType(contentType) && utf8.Valid(body) {
+		return string(body), ""
+	}
+
+	return "", base64.StdEncoding.EncodeToString(body)
+}
+
+// isTextContentType - Mirrors the content types the AWS provider returns `body` for, plus any
+// structured `+json` / `+xml` syntax
+func isTextContentType(contentType string) bool {
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	if strings.HasPrefix(mediaType, "text/") || strings.HasSuffix(mediaType, "+json") || strings.HasSuffix(mediaType, "+xml") {
+		return true
+	}
+
+	switch mediaType {
+	case "application/json",
+		"application/x-httpd-php",
+		"application/x-csh",
+		"application/x-sh",
+		"application/xml",
+		"application/x-sql",
+		"application/x-yaml",
+		"application/yaml",
+		"application/javascript",
+		"application/toml":
+		return true
+	}
+
+	return false
+}
+
+// verifyObjectETag - Single part uploads have the MD5 of the body as ETag. Multipart ETags
+// (`<md5>-<parts>`) and SSE-KMS/SSE-C ETags aren't MD5 digests of the body and can't be checked
+func verifyObjectETag(body []byte, output *s3.GetObjectOutput) error {
+
+	etag := strings.Trim(aws.StringValue(output.ETag), `"`)
+
+	if strings.HasPrefix(aws.StringValue(output.ServerSideEncryption), s3.ServerSideEncryptionAwsKms) || output.SSECustomerAlgorithm != nil {
+		log.Printf("[DEBUG] ETag (%s) of an SSE-KMS/SSE-C object is not an MD5 digest, skipping body verification", etag)
+		return nil
+	}
+
+	if len(etag) != hex.EncodedLen(md5.Size) || strings.Contains(etag, "-") {
+		log.Printf("[DEBUG] ETag (%s) is not an MD5 digest, skipping body verification", etag)
+		return nil
+	}
+
+	sum := md5.Sum(body)
+	if actual := hex.EncodeToString(sum[:]); !strings.EqualFold(actual, etag) {
+		return fmt.Errorf("body MD5 (%s) does not match ETag (%s)", actual, etag)
+	}
+
+	return nil
+}
+
+// objectID - `bucket/key`, w. `@version` appended when the object is versioned
+func objectID(bucket, key, versionID string) string {
+	if versionID == "" {
+		return fmt.Sprintf("%s/%s", bucket, key)
+	}
+	return fmt.Sprintf("%s/%s@%s", bucket, key, versionID)
+}
+
+// flattenObjectDate -
+func flattenObjectDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,128 @@
+package cortx
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"testing"
+)
+
+func TestObjectID(t *testing.T) {
+
+	cases := []struct {
+		bucket    string
+		key       string
+		versionID string
+		expected  string
+	}{
+		{"bucket", "key.json", "", "bucket/key.json"},
+		{"bucket", "dir/key.json", "", "bucket/dir/key.json"},
+		{"bucket", "dir/key.json", "v1", "bucket/dir/key.json@v1"},
+		{"bucket", "dir/key.json", "3HL4kqtJlcpXroDTDmJ+rmSpXd3dIbrHY", "bucket/dir/key.json@3HL4kqtJlcpXroDTDmJ+rmSpXd3dIbrHY"},
+		{"bucket", "dir/", "null", "bucket/dir/@null"},
+	}
+
+	for _, tc := range cases {
+		if got := objectID(tc.bucket, tc.key, tc.versionID); got != tc.expected {
+			t.Errorf("%s/%s (%s): expected %s, got %s", tc.bucket, tc.key, tc.versionID, tc.expected, got)
+		}
+	}
+}
+
+func TestFlattenObjectBody(t *testing.T) {
+
+	cases := []struct {
+		name        string
+		contentType string
+		body        []byte
+		text        string
+		encoded     string
+	}{
+		{"text", "text/plain", []byte("hello"), "hello", ""},
+		{"json w. charset", "application/json; charset=utf-8", []byte(`{"a":1}`), `{"a":1}`, ""},
+		{"binary", "application/octet-stream", []byte("hello"), "", "aGVsbG8="},
+		{"no content type", "", []byte("hello"), "", "aGVsbG8="},
+		{"text that isn't UTF-8", "text/plain", []byte{0xff, 0xfe}, "", "//4="},
+		{"empty text", "text/plain", []byte{}, "", ""},
+	}
+
+	for _, tc := range cases {
+		text, encoded := flattenObjectBody(tc.contentType, tc.body)
+
+		if text != tc.text || encoded != tc.encoded {
+			t.Errorf("%s: expected (%q, %q), got (%q, %q)", tc.name, tc.text, tc.encoded, text, encoded)
+		}
+	}
+}
+
+func TestIsTextContentType(t *testing.T) {
+
+	cases := map[string]bool{
+		"text/plain":                      true,
+		"text/csv; charset=utf-8":         true,
+		"application/json":                true,
+		"application/vnd.api+json":        true,
+		"application/atom+xml":            true,
+		"application/x-yaml":              true,
+		"application/octet-stream":        false,
+		"image/png":                       false,
+		"":                                false,
+		"application/json; charset=utf-8": true,
+	}
+
+	for contentType, expected := range cases {
+		if got := isTextContentType(contentType); got != expected {
+			t.Errorf("%q: expected %t, got %t", contentType, expected, got)
+		}
+	}
+}
+
+func TestVerifyObjectETag(t *testing.T) {
+
+	body := []byte(`{"version": 1}`)
+
+	if err := verifyObjectETag(body, &s3.GetObjectOutput{ETag: aws.String(`"d3e5a2b5a4b4c1a4e1d2a0f7d0b2b2c4"`)}); err == nil {
+		t.Errorf("expected mismatch error")
+	}
+
+	if err := verifyObjectETag(body, &s3.GetObjectOutput{ETag: aws.String(`"5d41402abc4b2a76b9719d911017c592-2"`)}); err != nil {
+		t.Errorf("expected multipart ETag to be skipped, got %v", err)
+	}
+
+	if err := verifyObjectETag([]byte("hello"), &s3.GetObjectOutput{ETag: aws.String(`"5d41402abc4b2a76b9719d911017c592"`)}); err != nil {
+		t.Errorf("expected matching ETag, got %v", err)
+	}
+}
+
+func TestVerifyObjectETagEncrypted(t *testing.T) {
+
+	body := []byte(`{"version": 1}`)
+
+	// Single part, 32 hex ETags that aren't the MD5 of the body
+	kms := &s3.GetObjectOutput{
+		ETag:                 aws.String(`"d3e5a2b5a4b4c1a4e1d2a0f7d0b2b2c4"`),
+		ServerSideEncryption: aws.String(s3.ServerSideEncryptionAwsKms),
+	}
+
+	if err := verifyObjectETag(body, kms); err != nil {
+		t.Errorf("expected SSE-KMS ETag to be skipped, got %v", err)
+	}
+
+	ssec := &s3.GetObjectOutput{
+		ETag:                 aws.String(`"d3e5a2b5a4b4c1a4e1d2a0f7d0b2b2c4"`),
+		SSECustomerAlgorithm: aws.String("AES256"),
+	}
+
+	if err := verifyObjectETag(body, ssec); err != nil {
+		t.Errorf("expected SSE-C ETag to be skipped, got %v", err)
+	}
+
+	// SSE-S3 ETags are still the MD5 of the body
+	sses3 := &s3.GetObjectOutput{
+		ETag:                 aws.String(`"d3e5a2b5a4b4c1a4e1d2a0f7d0b2b2c4"`),
+		ServerSideEncryption: aws.String(s3.ServerSideEncryptionAes256),
+	}
+
+	if err := verifyObjectETag(body, sses3); err == nil {
+		t.Errorf("expected SSE-S3 mismatch error")
+	}
+}
@@ -0,0 +1,319 @@
+package cortx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"sort"
+	"strings"
+	"time"
+)
+
+// objectVersion is a single entry of ListObjectVersions, either an object version or a delete marker.
+type objectVersion struct {
+	Key            string
+	VersionID      string
+	IsLatest       bool
+	IsDeleteMarker bool
+	Size           int64
+	ETag           string
+	LastModified   time.Time
+
+	// Only looked up on buckets w. object lock enabled
+	LockMode        string
+	LockRetainUntil *time.Time
+	LegalHoldStatus string
+}
+
+// datasourceObjectVersions
+//
+// Lists the versions and delete markers of a key (or every key under a prefix, or the whole
+// bucket), newest first for each key. Rollbacks pin `cortx_object` to `versions[1].version_id`
+func datasourceObjectVersions() *schema.Resource {
+
+	return &schema.Resource{
+		ReadContext: datasourceObjectVersionsRead,
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"key": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"prefix"},
+			},
+			"prefix": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"key"},
+			},
+			"latest_n": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"version_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"versions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_latest": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"is_delete_marker": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"etag": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"last_modified": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"object_lock_mode": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"object_lock_retain_until_date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"object_lock_legal_hold_status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// datasourceObjectVersionsRead -
+func datasourceObjectVersionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	client := meta.(*CortxClient).S3
+
+	bucket := d.Get("bucket").(string)
+	key := d.Get("key").(string)
+	prefix := d.Get("prefix").(string)
+
+	if key != "" {
+		prefix = key
+	}
+
+	versions, err := listObjectVersions(ctx, client, bucket, prefix, key)
+
+	if err != nil {
+		return append(diags, OperationErrorDiagnostic("ListObjectVersions", bucket, err))
+	}
+
+	versions = latestObjectVersions(versions, d.Get("latest_n").(int))
+
+	if err := lookupObjectVersionLocks(ctx, client, bucket, versions); err != nil {
+		return append(diags, OperationErrorDiagnostic("HeadObject", bucket, err))
+	}
+
+	versionIDs := make([]string, 0, len(versions))
+	for _, v := range versions {
+		versionIDs = append(versionIDs, v.VersionID)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", bucket, prefix))
+	d.Set("version_ids", versionIDs)
+
+	if err := d.Set("versions", flattenObjectVersions(versions)); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("[ERROR] Failed setting versions (%s):", d.Id()),
+			Detail:   fmt.Sprintf("[ERROR] %v", err),
+		})
+		return diags
+	}
+
+	return diags
+}
+
+// listObjectVersions - Versions and delete markers under `prefix`, or of exactly `key` when set (keys
+// are listed in order, paging stops once past `key`). Sorted by key, then newest first
+func listObjectVersions(ctx context.Context, client *s3.S3, bucket, prefix, key string) ([]*objectVersion, error) {
+
+	var versions []*objectVersion
+
+	_, err := forEachObjectVersionsPage(ctx, client, bucket, prefix, func(ctx context.Context, client *s3.S3, bucket string, page *s3.ListObjectVersionsOutput) (int64, error) {
+
+		for _, v := range page.Versions {
+			versions = append(versions, &objectVersion{
+				Key:          aws.StringValue(v.Key),
+				VersionID:    aws.StringValue(v.VersionId),
+				IsLatest:     aws.BoolValue(v.IsLatest),
+				Size:         aws.Int64Value(v.Size),
+				ETag:         strings.Trim(aws.StringValue(v.ETag), `"`),
+				LastModified: aws.TimeValue(v.LastModified),
+			})
+		}
+
+		for _, v := range page.DeleteMarkers {
+			versions = append(versions, &objectVersion{
+				Key:            aws.StringValue(v.Key),
+				VersionID:      aws.StringValue(v.VersionId),
+				IsLatest:       aws.BoolValue(v.IsLatest),
+				IsDeleteMarker: true,
+				LastModified:   aws.TimeValue(v.LastModified),
+			})
+		}
+
+		if key != "" && aws.StringValue(page.NextKeyMarker) > key {
+			return 0, errStopPaging
+		}
+
+		return 0, nil
+	})
+
+	if err != nil && !errors.Is(err, errStopPaging) {
+		return nil, err
+	}
+
+	if key != "" {
+		exact := versions[:0]
+		for _, v := range versions {
+			if v.Key == key {
+				exact = append(exact, v)
+			}
+		}
+		versions = exact
+	}
+
+	sortObjectVersions(versions)
+
+	return versions, nil
+}
+
+// sortObjectVersions - Versions and delete markers are listed separately, merge them back into
+// a single newest first history per key. The latest version wins a tie
+func sortObjectVersions(versions []*objectVersion) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		a, b := versions[i], versions[j]
+
+		if a.Key != b.Key {
+			return a.Key < b.Key
+		}
+
+		if !a.LastModified.Equal(b.LastModified) {
+			return a.LastModified.After(b.LastModified)
+		}
+
+		return a.IsLatest && !b.IsLatest
+	})
+}
+
+// latestObjectVersions - Keeps the `n` newest entries of each key (0 keeps everything), expects
+// sorted input
+func latestObjectVersions(versions []*objectVersion, n int) []*objectVersion {
+
+	if n == 0 {
+		return versions
+	}
+
+	var (
+		latest []*objectVersion
+		key    string
+		count  int
+	)
+
+	for _, v := range versions {
+		if v.Key != key {
+			key, count = v.Key, 0
+		}
+
+		if count < n {
+			latest = append(latest, v)
+		}
+
+		count++
+	}
+
+	return latest
+}
+
+// lookupObjectVersionLocks - Retention and legal hold aren't part of the listing, they're looked
+// up per version w. HeadObject, and only on buckets w. object lock enabled
+func lookupObjectVersionLocks(ctx context.Context, client *s3.S3, bucket string, versions []*objectVersion) error {
+
+	if enabled, err := bucketObjectLockEnabled(ctx, client, bucket); err != nil || !enabled {
+		return err
+	}
+
+	for _, v := range versions {
+		if v.IsDeleteMarker {
+			continue
+		}
+
+		output, err := client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+			Bucket:    aws.String(bucket),
+			Key:       aws.String(v.Key),
+			VersionId: aws.String(v.VersionID),
+		})
+
+		if err != nil {
+			return fmt.Errorf("checking object lock: %w", newObjectVersionError(v.Key, v.VersionID, err))
+		}
+
+		v.LockMode = aws.StringValue(output.ObjectLockMode)
+		v.LockRetainUntil = output.ObjectLockRetainUntilDate
+		v.LegalHoldStatus = aws.StringValue(output.ObjectLockLegalHoldStatus)
+	}
+
+	return nil
+}
+
+// flattenObjectVersions -
+func flattenObjectVersions(versions []*objectVersion) []interface{} {
+
+	l := make([]interface{}, 0, len(versions))
+
+	for _, v := range versions {
+		l = append(l, map[string]interface{}{
+			"key":                           v.Key,
+			"version_id":                    v.VersionID,
+			"is_latest":                     v.IsLatest,
+			"is_delete_marker":              v.IsDeleteMarker,
+			"size":                          int(v.Size),
+			"etag":                          v.ETag,
+			"last_modified":                 v.LastModified.Format(time.RFC3339),
+			"object_lock_mode":              v.LockMode,
+			"object_lock_retain_until_date": flattenObjectDate(v.LockRetainUntil),
+			"object_lock_legal_hold_status": v.LegalHoldStatus,
+		})
+	}
+
+	return l
+}
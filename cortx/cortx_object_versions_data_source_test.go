@@ -0,0 +1,45 @@
+package cortx
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSortAndLimitObjectVersions(t *testing.T) {
+
+	t0 := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	versions := []*objectVersion{
+		{Key: "b", VersionID: "b1", LastModified: t0},
+		{Key: "a", VersionID: "a1", LastModified: t0},
+		{Key: "a", VersionID: "a3", LastModified: t0.Add(2 * time.Hour), IsLatest: true},
+		{Key: "a", VersionID: "a2", LastModified: t0.Add(time.Hour)},
+		{Key: "a", VersionID: "dm", LastModified: t0.Add(2 * time.Hour), IsDeleteMarker: true},
+	}
+
+	sortObjectVersions(versions)
+
+	expected := []string{"a3", "dm", "a2", "a1", "b1"}
+	for i, v := range versions {
+		if v.VersionID != expected[i] {
+			t.Fatalf("unexpected order at %d: got %s, expected %s", i, v.VersionID, expected[i])
+		}
+	}
+
+	latest := latestObjectVersions(versions, 2)
+
+	expected = []string{"a3", "dm", "b1"}
+	if len(latest) != len(expected) {
+		t.Fatalf("expected %d versions, got %d", len(expected), len(latest))
+	}
+
+	for i, v := range latest {
+		if v.VersionID != expected[i] {
+			t.Errorf("unexpected latest version at %d: got %s, expected %s", i, v.VersionID, expected[i])
+		}
+	}
+
+	if got := latestObjectVersions(versions, 0); len(got) != len(versions) {
+		t.Errorf("expected latest_n = 0 to keep every version, got %d", len(got))
+	}
+}
@@ -0,0 +1,248 @@
+package cortx
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// objectsDataSourceKeyLimit - Upper bound on `max_keys`, keeps a listing of a huge bucket from
+// paging forever (and from bloating state)
+const objectsDataSourceKeyLimit = 100000
+
+// datasourceObjects
+//
+// Lists keys (and common prefixes) of a bucket w. ListObjectsV2, modeled on the AWS provider's
+// `aws_s3_objects`. Paging stops once `max_keys` keys and common prefixes were returned
+//
+// See: https://github.com/hashicorp/terraform-provider-aws/blob/main/internal/service/s3/objects_data_source.go
+func datasourceObjects() *schema.Resource {
+
+	return &schema.Resource{
+		ReadContext: datasourceObjectsRead,
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"prefix": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"delimiter": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"start_after": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"max_keys": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1000,
+				ValidateFunc: validation.IntBetween(1, objectsDataSourceKeyLimit),
+			},
+			"encoding_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(s3.EncodingType_Values(), false),
+			},
+			"keys": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"common_prefixes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"objects": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"etag": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"last_modified": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"storage_class": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"truncated": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// datasourceObjectsRead -
+func datasourceObjectsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	var (
+		diags          diag.Diagnostics
+		objects        []*s3.Object
+		commonPrefixes []string
+		truncated      bool
+		decodeErr      error
+	)
+
+	client := meta.(*CortxClient).S3
+
+	bucket := d.Get("bucket").(string)
+	prefix := d.Get("prefix").(string)
+	maxKeys := d.Get("max_keys").(int)
+
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+	}
+
+	if prefix != "" {
+		input.Prefix = aws.String(prefix)
+	}
+
+	if v, ok := d.GetOk("delimiter"); ok {
+		input.Delimiter = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("start_after"); ok {
+		input.StartAfter = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("encoding_type"); ok {
+		input.EncodingType = aws.String(v.(string))
+	}
+
+	// Don't ask for more than is left, the server caps each page at 1000 anyway
+	if maxKeys < 1000 {
+		input.MaxKeys = aws.Int64(int64(maxKeys))
+	}
+
+	err := client.ListObjectsV2PagesWithContext(ctx, input, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+
+		encoded := aws.StringValue(page.EncodingType) == s3.EncodingTypeUrl
+
+		for _, v := range page.CommonPrefixes {
+			p, err := decodeListedKey(aws.StringValue(v.Prefix), encoded)
+			if err != nil {
+				decodeErr = err
+				return false
+			}
+			commonPrefixes = append(commonPrefixes, p)
+		}
+
+		for _, v := range page.Contents {
+			key, err := decodeListedKey(aws.StringValue(v.Key), encoded)
+			if err != nil {
+				decodeErr = err
+				return false
+			}
+			v.Key = aws.String(key)
+			objects = append(objects, v)
+		}
+
+		if n := len(objects) + len(commonPrefixes); n >= maxKeys {
+			truncated = n > maxKeys || aws.BoolValue(page.IsTruncated)
+			return false
+		}
+
+		return !lastPage
+	})
+
+	if err == nil {
+		err = decodeErr
+	}
+
+	if err != nil {
+		return append(diags, OperationErrorDiagnostic("ListObjectsV2", bucket, err))
+	}
+
+	// Trim the last page to `max_keys` - Keys first, then common prefixes
+	if len(objects) > maxKeys {
+		objects = objects[:maxKeys]
+	}
+
+	if len(objects)+len(commonPrefixes) > maxKeys {
+		commonPrefixes = commonPrefixes[:maxKeys-len(objects)]
+	}
+
+	keys := make([]string, 0, len(objects))
+	for _, v := range objects {
+		keys = append(keys, aws.StringValue(v.Key))
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", bucket, prefix))
+	d.Set("keys", keys)
+	d.Set("common_prefixes", commonPrefixes)
+	d.Set("truncated", truncated)
+
+	if err := d.Set("objects", flattenListedObjects(objects)); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("[ERROR] Failed setting objects (%s):", d.Id()),
+			Detail:   fmt.Sprintf("[ERROR] %v", err),
+		})
+		return diags
+	}
+
+	return diags
+}
+
+// decodeListedKey - W. `encoding_type = "url"` keys and prefixes come back form encoded (s.t. keys
+// w. characters XML can't carry can be listed)
+func decodeListedKey(key string, encoded bool) (string, error) {
+
+	if !encoded {
+		return key, nil
+	}
+
+	decoded, err := url.QueryUnescape(key)
+	if err != nil {
+		return "", fmt.Errorf("decoding listed key (%s): %w", key, err)
+	}
+
+	return decoded, nil
+}
+
+// flattenListedObjects -
+func flattenListedObjects(objects []*s3.Object) []interface{} {
+
+	l := make([]interface{}, 0, len(objects))
+
+	for _, v := range objects {
+		l = append(l, map[string]interface{}{
+			"key":           aws.StringValue(v.Key),
+			"size":          int(aws.Int64Value(v.Size)),
+			"etag":          strings.Trim(aws.StringValue(v.ETag), `"`),
+			"last_modified": aws.TimeValue(v.LastModified).Format(time.RFC3339),
+			"storage_class": aws.StringValue(v.StorageClass),
+		})
+	}
+
+	return l
+}
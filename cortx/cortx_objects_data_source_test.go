@@ -0,0 +1,20 @@
+package cortx
+
+import (
+	"testing"
+)
+
+func TestDecodeListedKey(t *testing.T) {
+
+	if got, _ := decodeListedKey("builds/a+b%2Bc.tar.gz", false); got != "builds/a+b%2Bc.tar.gz" {
+		t.Errorf("expected unencoded key unchanged, got %s", got)
+	}
+
+	if got, _ := decodeListedKey("builds/a+b%2Bc.tar.gz", true); got != "builds/a b+c.tar.gz" {
+		t.Errorf("unexpected decoded key: %s", got)
+	}
+
+	if _, err := decodeListedKey("builds/%zz", true); err == nil {
+		t.Errorf("expected error decoding invalid escape")
+	}
+}
@@ -0,0 +1,282 @@
+package cortx
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"time"
+)
+
+//
+// NOTE: aws-sdk-go (v1) has no POST policy builder, the policy and its SigV4 signature follow:
+// https://docs.aws.amazon.com/AmazonS3/latest/API/sigv4-HTTPPOSTConstructPolicy.html
+//
+
+const (
+	postPolicyAlgorithm  = "AWS4-HMAC-SHA256"
+	postPolicyDateFormat = "20060102T150405Z"
+)
+
+// postPolicy is a browser upload (HTML form POST) policy for a single bucket, it's signed w. the
+// provider's credentials and yields the form fields a client submits alongside the file.
+type postPolicy struct {
+	Bucket            string
+	Key               string // Exact key, or
+	KeyPrefix         string // `starts-with` condition, the form's key is `<prefix>${filename}`
+	ContentType       string // Exact content type, or
+	ContentTypePrefix string // `starts-with` condition
+	ContentLengthMin  int64
+	ContentLengthMax  int64     // 0 - No content-length-range condition
+	Date              time.Time // Signing time
+	Expiration        time.Time
+}
+
+// datasourcePresignedPost
+//
+// Builds and signs a POST policy for browser uploads straight to a bucket, returning the form
+// action URL and the fields to submit w. the file (`file` must be the last form field)
+func datasourcePresignedPost() *schema.Resource {
+
+	return &schema.Resource{
+		ReadContext: datasourcePresignedPostRead,
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"key": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"key_prefix"},
+			},
+			"key_prefix": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"key"},
+			},
+			"content_type": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"content_type_prefix"},
+			},
+			"content_type_prefix": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"content_type"},
+			},
+			"content_length_min": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"content_length_max": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"expires_in": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      int(presignedURLDefaultExpiry.Seconds()),
+				ValidateFunc: validation.IntBetween(1, int(presignedURLMaxExpiry.Seconds())),
+			},
+			"url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"fields": {
+				Type:      schema.TypeMap,
+				Computed:  true,
+				Sensitive: true,
+				Elem:      &schema.Schema{Type: schema.TypeString},
+			},
+			"expiration": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// datasourcePresignedPostRead -
+func datasourcePresignedPostRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+
+	conn := meta.(*CortxClient)
+	bucket := d.Get("bucket").(string)
+
+	now := time.Now().UTC()
+	policy := &postPolicy{
+		Bucket:            bucket,
+		Key:               d.Get("key").(string),
+		KeyPrefix:         d.Get("key_prefix").(string),
+		ContentType:       d.Get("content_type").(string),
+		ContentTypePrefix: d.Get("content_type_prefix").(string),
+		ContentLengthMin:  int64(d.Get("content_length_min").(int)),
+		ContentLengthMax:  int64(d.Get("content_length_max").(int)),
+		Date:              now,
+		Expiration:        now.Add(time.Duration(d.Get("expires_in").(int)) * time.Second),
+	}
+
+	if policy.ContentLengthMax > 0 && policy.ContentLengthMin > policy.ContentLengthMax {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("[ERROR] Invalid POST policy (%s):", bucket),
+			Detail: fmt.Sprintf(
+				"[ERROR] content_length_min (%d) is larger than content_length_max (%d)",
+				policy.ContentLengthMin, policy.ContentLengthMax,
+			),
+		})
+		return diags
+	}
+
+	creds, err := conn.S3.Config.Credentials.GetWithContext(ctx)
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("[ERROR] Failed getting credentials for POST policy (%s):", bucket),
+			Detail:   fmt.Sprintf("[ERROR] %v", err),
+		})
+		return diags
+	}
+
+	fields, err := policy.Sign(creds, aws.StringValue(conn.S3.Config.Region))
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("[ERROR] Failed signing POST policy (%s):", bucket),
+			Detail:   fmt.Sprintf("[ERROR] %v", err),
+		})
+		return diags
+	}
+
+	// Form action - Same addressing style as every other request the provider sends
+	pathStyleURL, virtualHostedURL, err := bucketURLs(conn.Endpoint, bucket)
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("[ERROR] Failed parsing endpoint (%s):", conn.Endpoint),
+			Detail:   fmt.Sprintf("[ERROR] %v", err),
+		})
+		return diags
+	}
+
+	formURL := virtualHostedURL
+	if aws.BoolValue(conn.S3.Config.S3ForcePathStyle) {
+		formURL = pathStyleURL
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s%s", bucket, policy.KeyPrefix, policy.Key))
+	d.Set("url", formURL)
+	d.Set("fields", fields)
+	d.Set("expiration", policy.Expiration.Format(time.RFC3339))
+
+	return diags
+}
+
+// Sign - Returns the form fields, the base64 policy document and its SigV4 signature included
+func (p *postPolicy) Sign(creds credentials.Value, region string) (map[string]interface{}, error) {
+
+	date := p.Date.UTC()
+	credential := fmt.Sprintf("%s/%s/%s/s3/aws4_request", creds.AccessKeyID, date.Format("20060102"), region)
+
+	fields := map[string]interface{}{
+		"x-amz-algorithm":  postPolicyAlgorithm,
+		"x-amz-credential": credential,
+		"x-amz-date":       date.Format(postPolicyDateFormat),
+	}
+
+	if creds.SessionToken != "" {
+		fields["x-amz-security-token"] = creds.SessionToken
+	}
+
+	if p.Key != "" {
+		fields["key"] = p.Key
+	} else {
+		fields["key"] = p.KeyPrefix + "${filename}"
+	}
+
+	if p.ContentType != "" {
+		fields["Content-Type"] = p.ContentType
+	}
+
+	document, err := json.Marshal(map[string]interface{}{
+		"expiration": p.Expiration.UTC().Format("2006-01-02T15:04:05.000Z"),
+		"conditions": p.conditions(fields),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	policy := base64.StdEncoding.EncodeToString(document)
+	signingKey := postPolicySigningKey(creds.SecretAccessKey, date.Format("20060102"), region, "s3")
+
+	fields["policy"] = policy
+	fields["x-amz-signature"] = hex.EncodeToString(hmacSHA256(signingKey, []byte(policy)))
+
+	return fields, nil
+}
+
+// conditions - Every field submitted w. the form (except the policy and signature) must be
+// matched by a condition
+func (p *postPolicy) conditions(fields map[string]interface{}) []interface{} {
+
+	conditions := []interface{}{
+		map[string]string{"bucket": p.Bucket},
+	}
+
+	if p.Key != "" {
+		conditions = append(conditions, map[string]string{"key": p.Key})
+	} else {
+		conditions = append(conditions, []string{"starts-with", "$key", p.KeyPrefix})
+	}
+
+	if p.ContentType != "" {
+		conditions = append(conditions, map[string]string{"Content-Type": p.ContentType})
+	} else if p.ContentTypePrefix != "" {
+		conditions = append(conditions, []string{"starts-with", "$Content-Type", p.ContentTypePrefix})
+	}
+
+	if p.ContentLengthMax > 0 {
+		conditions = append(conditions, []interface{}{"content-length-range", p.ContentLengthMin, p.ContentLengthMax})
+	}
+
+	for _, k := range []string{"x-amz-algorithm", "x-amz-credential", "x-amz-date", "x-amz-security-token"} {
+		if v, ok := fields[k]; ok {
+			conditions = append(conditions, map[string]interface{}{k: v})
+		}
+	}
+
+	return conditions
+}
+
+// postPolicySigningKey - The SigV4 signing key, see:
+// https://docs.aws.amazon.com/general/latest/gr/sigv4-calculate-signature.html
+func postPolicySigningKey(secret, date, region, service string) []byte {
+	key := hmacSHA256([]byte("AWS4"+secret), []byte(date))
+	key = hmacSHA256(key, []byte(region))
+	key = hmacSHA256(key, []byte(service))
+	return hmacSHA256(key, []byte("aws4_request"))
+}
+
+// hmacSHA256 -
+func hmacSHA256(key, data []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write(data)
+	return h.Sum(nil)
+}
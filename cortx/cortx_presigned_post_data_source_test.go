@@ -0,0 +1,78 @@
+package cortx
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"testing"
+	"time"
+)
+
+func TestPostPolicySigningKey(t *testing.T) {
+
+	// https://docs.aws.amazon.com/general/latest/gr/signature-v4-examples.html
+	key := postPolicySigningKey("wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "20120215", "us-east-1", "iam")
+
+	if got, want := hex.EncodeToString(key), "f4780e2d9f65fa895f9c67b32ce1baf0b0d8a43505a000a1a9e090d414db404d"; got != want {
+		t.Errorf("expected signing key %s, got %s", want, got)
+	}
+}
+
+func TestPostPolicySign(t *testing.T) {
+
+	date := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	policy := &postPolicy{
+		Bucket:            "uploads",
+		KeyPrefix:         "incoming/",
+		ContentTypePrefix: "image/",
+		ContentLengthMax:  1024,
+		Date:              date,
+		Expiration:        date.Add(15 * time.Minute),
+	}
+
+	fields, err := policy.Sign(credentials.Value{AccessKeyID: "AK", SecretAccessKey: "SK"}, "us-east-1")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	expected := map[string]string{
+		"key":              "incoming/${filename}",
+		"x-amz-algorithm":  postPolicyAlgorithm,
+		"x-amz-credential": "AK/20220601/us-east-1/s3/aws4_request",
+		"x-amz-date":       "20220601T120000Z",
+	}
+
+	for k, v := range expected {
+		if fields[k] != v {
+			t.Errorf("expected field %s = %s, got %v", k, v, fields[k])
+		}
+	}
+
+	if _, ok := fields["x-amz-security-token"]; ok {
+		t.Errorf("expected no security token field for static credentials")
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(fields["policy"].(string))
+	if err != nil {
+		t.Fatalf("expected base64 policy, got %v", err)
+	}
+
+	var document struct {
+		Expiration string        `json:"expiration"`
+		Conditions []interface{} `json:"conditions"`
+	}
+
+	if err := json.Unmarshal(raw, &document); err != nil {
+		t.Fatalf("expected JSON policy, got %v", err)
+	}
+
+	if document.Expiration != "2022-06-01T12:15:00.000Z" {
+		t.Errorf("unexpected expiration %s", document.Expiration)
+	}
+
+	// bucket, key, Content-Type, content-length-range & algorithm/credential/date
+	if len(document.Conditions) != 7 {
+		t.Errorf("expected 7 conditions, got %d: %v", len(document.Conditions), document.Conditions)
+	}
+}
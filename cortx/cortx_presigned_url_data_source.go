@@ -0,0 +1,200 @@
+package cortx
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net/http"
+	"time"
+)
+
+const (
+	presignedURLDefaultExpiry = 15 * time.Minute
+	presignedURLMaxExpiry     = 7 * 24 * time.Hour // SigV4 upper bound
+)
+
+// datasourcePresignedURL
+//
+// Generates a SigV4 presigned URL for a GET or PUT of a single object. The URL is signed w. the
+// provider's credentials against the provider's endpoint (path style), and is regenerated on
+// every read
+func datasourcePresignedURL() *schema.Resource {
+
+	return &schema.Resource{
+		ReadContext: datasourcePresignedURLRead,
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"key": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"method": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      http.MethodGet,
+				ValidateFunc: validation.StringInSlice([]string{http.MethodGet, http.MethodPut}, false),
+			},
+			"expires_in": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      int(presignedURLDefaultExpiry.Seconds()),
+				ValidateFunc: validation.IntBetween(1, int(presignedURLMaxExpiry.Seconds())),
+			},
+			"content_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"content_md5": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateContentMD5,
+			},
+			"version_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"url": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"signed_headers": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"expiration": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// datasourcePresignedURLRead -
+func datasourcePresignedURLRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	var (
+		diags diag.Diagnostics
+		req   *request.Request
+	)
+
+	client := meta.(*CortxClient).S3
+
+	bucket := d.Get("bucket").(string)
+	key := d.Get("key").(string)
+	method := d.Get("method").(string)
+	expiry := time.Duration(d.Get("expires_in").(int)) * time.Second
+
+	contentType := d.Get("content_type").(string)
+	contentMD5 := d.Get("content_md5").(string)
+	versionID := d.Get("version_id").(string)
+
+	// Content constraints only apply to uploads, version IDs only to downloads
+	if err := checkPresignedURLArguments(method, contentType, contentMD5, versionID); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("[ERROR] Invalid presigned URL arguments (%s/%s):", bucket, key),
+			Detail:   fmt.Sprintf("[ERROR] %v", err),
+		})
+		return diags
+	}
+
+	switch method {
+	case http.MethodPut:
+		input := &s3.PutObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+		}
+
+		if contentType != "" {
+			input.ContentType = aws.String(contentType)
+		}
+
+		if contentMD5 != "" {
+			input.ContentMD5 = aws.String(contentMD5)
+		}
+
+		req, _ = client.PutObjectRequest(input)
+
+	default:
+		input := &s3.GetObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+		}
+
+		if versionID != "" {
+			input.VersionId = aws.String(versionID)
+		}
+
+		req, _ = client.GetObjectRequest(input)
+	}
+
+	req.SetContext(ctx)
+
+	// Signed at read time, the expiration is relative to now
+	expiration := time.Now().UTC().Add(expiry)
+
+	url, headers, err := req.PresignRequest(expiry)
+
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("[ERROR] Failed presigning %s (%s/%s):", method, bucket, key),
+			Detail:   fmt.Sprintf("[ERROR] %v", err),
+		})
+		return diags
+	}
+
+	// Headers that were signed and must be sent as-is w. the request
+	signedHeaders := make(map[string]interface{}, len(headers))
+	for k := range headers {
+		signedHeaders[k] = headers.Get(k)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", method, bucket, key))
+	d.Set("url", url)
+	d.Set("signed_headers", signedHeaders)
+	d.Set("expiration", expiration.Format(time.RFC3339))
+
+	return diags
+}
+
+// checkPresignedURLArguments -
+func checkPresignedURLArguments(method, contentType, contentMD5, versionID string) error {
+
+	if method == http.MethodGet && (contentType != "" || contentMD5 != "") {
+		return fmt.Errorf("content_type and content_md5 constrain uploads, they require method = %q", http.MethodPut)
+	}
+
+	if method == http.MethodPut && versionID != "" {
+		return fmt.Errorf("version_id selects the version to download, it requires method = %q", http.MethodGet)
+	}
+
+	return nil
+}
+
+// validateContentMD5 - The base64 encoded (binary, 16 byte) MD5 digest, not the hex digest
+func validateContentMD5(i interface{}, k string) (warnings []string, errors []error) {
+
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return warnings, errors
+	}
+
+	if digest, err := base64.StdEncoding.DecodeString(v); err != nil || len(digest) != 16 {
+		errors = append(errors, fmt.Errorf("%q must be a base64 encoded MD5 digest (16 bytes), got: %s", k, v))
+	}
+
+	return warnings, errors
+}
@@ -0,0 +1,36 @@
+package cortx
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestCheckPresignedURLArguments(t *testing.T) {
+
+	if err := checkPresignedURLArguments(http.MethodGet, "", "", "v1"); err != nil {
+		t.Errorf("expected versioned GET to be valid, got %v", err)
+	}
+
+	if err := checkPresignedURLArguments(http.MethodPut, "application/json", "XUFAKrxLKna5cZ2REBfFkg==", ""); err != nil {
+		t.Errorf("expected constrained PUT to be valid, got %v", err)
+	}
+
+	if err := checkPresignedURLArguments(http.MethodGet, "application/json", "", ""); err == nil {
+		t.Errorf("expected error for content_type on GET")
+	}
+
+	if err := checkPresignedURLArguments(http.MethodPut, "", "", "v1"); err == nil {
+		t.Errorf("expected error for version_id on PUT")
+	}
+}
+
+func TestValidateContentMD5(t *testing.T) {
+
+	if _, errs := validateContentMD5("XUFAKrxLKna5cZ2REBfFkg==", "content_md5"); len(errs) > 0 {
+		t.Errorf("expected valid digest, got %v", errs)
+	}
+
+	if _, errs := validateContentMD5("5d41402abc4b2a76b9719d911017c592", "content_md5"); len(errs) == 0 {
+		t.Errorf("expected hex digest to be rejected")
+	}
+}
@@ -2,18 +2,64 @@ package cortx
 
 import (
 	"context"
+	"fmt"
 	"github.com/hashicorp/aws-sdk-go-base/v2/awsv1shim/v2/tfawserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"net/http"
 	"time"
 )
 
 const (
-	ErrCodeOperationAborted = "OperationAborted"
-	ErrCodeBucketNotEmpty   = "BucketNotEmpty"
-	ErrCodeAccessDenied     = "AccessDenied"
-	ErrCodeNoSuchTagSet     = "NoSuchTagSet"
+	ErrCodeOperationAborted                = "OperationAborted"
+	ErrCodeBucketNotEmpty                  = "BucketNotEmpty"
+	ErrCodeAccessDenied                    = "AccessDenied"
+	ErrCodeNoSuchTagSet                    = "NoSuchTagSet"
+	ErrCodeNoSuchBucketPolicy              = "NoSuchBucketPolicy"
+	ErrCodeNoSuchCORSConfiguration         = "NoSuchCORSConfiguration"
+	ErrCodeNoSuchLifecycleConfiguration    = "NoSuchLifecycleConfiguration"
+	ErrCodeObjectLockConfigurationNotFound = "ObjectLockConfigurationNotFoundError"
+	ErrCodeInvalidBucketState              = "InvalidBucketState"
+	ErrCodeNotImplemented                  = "NotImplemented"
+	ErrCodeMethodNotAllowed                = "MethodNotAllowed"
 )
 
+// NotSupportedByServer - Returns true if the CORTX server rejected a request because the
+// API isn't implemented in the running CORTX version. As of 6/15/2022 CORTX answers
+// unimplemented S3 APIs w. MethodNotAllowed (405) or NotImplemented (501), see:
+// https://seagate-systems.atlassian.net/wiki/spaces/PUB/pages/759333066/CORTX+S3+API+Guide
+func NotSupportedByServer(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	return tfawserr.ErrCodeEquals(err, ErrCodeNotImplemented, ErrCodeMethodNotAllowed) ||
+		tfawserr.ErrStatusCodeEquals(err, http.StatusMethodNotAllowed) ||
+		tfawserr.ErrStatusCodeEquals(err, http.StatusNotImplemented)
+}
+
+// OperationErrorDiagnostic - Builds the diagnostic for a failed API call against a bucket, calling
+// out servers that don't implement the API rather than returning the raw error
+func OperationErrorDiagnostic(operation, bucket string, err error) diag.Diagnostic {
+
+	if NotSupportedByServer(err) {
+		return diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("[ERROR] %s not supported by this CORTX server (%s):", operation, bucket),
+			Detail: fmt.Sprintf(
+				"[ERROR] The CORTX server rejected %s, this API is not available in the running CORTX version: %v",
+				operation, err,
+			),
+		}
+	}
+
+	return diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("[ERROR] Failed on %s (%s):", operation, bucket),
+		Detail:   fmt.Sprintf("[ERROR] %v", err),
+	}
+}
+
 // Retryable is a function that is used to decide if a function's error is retryable or not.
 type Retryable func(error) (bool, error)
 
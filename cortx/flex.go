@@ -0,0 +1,35 @@
+package cortx
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+//
+// NOTE: Trimmed Down Version of the AWS Provider's `flex` Package
+// See: https://github.com/hashicorp/terraform-provider-aws/blob/main/internal/flex/flex.go
+//
+
+// ExpandStringSet - Converts a *schema.Set of strings to a slice of string pointers
+func ExpandStringSet(configured *schema.Set) []*string {
+	if configured == nil {
+		return nil
+	}
+
+	vs := make([]*string, 0, configured.Len())
+	for _, v := range configured.List() {
+		if val, ok := v.(string); ok && val != "" {
+			vs = append(vs, aws.String(val))
+		}
+	}
+	return vs
+}
+
+// FlattenStringSet - Converts a slice of string pointers to a *schema.Set
+func FlattenStringSet(list []*string) *schema.Set {
+	vs := make([]interface{}, 0, len(list))
+	for _, v := range list {
+		vs = append(vs, aws.StringValue(v))
+	}
+	return schema.NewSet(schema.HashString, vs)
+}
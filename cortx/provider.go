@@ -11,6 +11,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// CortxClient - Provider meta, the S3 client along w. the provider level settings resources need
+type CortxClient struct {
+	S3       *s3.S3
+	Endpoint string
+	AuditLog *AuditLog // nil unless `audit_log_path` is set
+}
+
 // Provider
 func Provider() *schema.Provider {
 
@@ -44,12 +51,29 @@ func Provider() *schema.Provider {
 				Sensitive:   true,
 				DefaultFunc: schema.EnvDefaultFunc("CORTX_SECRET_ACCESS_KEY", nil),
 			},
+			"audit_log_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CORTX_AUDIT_LOG_PATH", nil),
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"cortx_bucket": resourceBucket(),
+			"cortx_bucket":                           resourceBucket(),
+			"cortx_bucket_cors_configuration":        resourceBucketCorsConfiguration(),
+			"cortx_bucket_lifecycle_configuration":   resourceBucketLifecycleConfiguration(),
+			"cortx_bucket_expiry_sweep":              resourceBucketExpirySweep(),
+			"cortx_bucket_object_lock_configuration": resourceBucketObjectLockConfiguration(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"cortx_bucket": datasourceBucket(),
+			"cortx_bucket":          datasourceBucket(),
+			"cortx_bucket_usage":    datasourceBucketUsage(),
+			"cortx_buckets":         datasourceBuckets(),
+			"cortx_caller_identity": datasourceCallerIdentity(),
+			"cortx_object":          datasourceObject(),
+			"cortx_object_versions": datasourceObjectVersions(),
+			"cortx_objects":         datasourceObjects(),
+			"cortx_presigned_post":  datasourcePresignedPost(),
+			"cortx_presigned_url":   datasourcePresignedURL(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
@@ -74,13 +98,13 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 	// Authentication Method - CORTX_ACCESS_KEY and CORTX_SECRET_ACCESS_KEY
 	if (cortx_access_key != "") && (cortx_secret_access_key != "") {
 
+		endpoint := fmt.Sprintf("http://%s:%s", cortx_endpoint_host, cortx_endpoint_port)
+
 		// Initialize a New S3 Client Connection
 		sess, err := session.NewSession(
 			&aws.Config{
-				Credentials: credentials.NewStaticCredentials(cortx_access_key, cortx_secret_access_key, ""),
-				Endpoint: aws.String(
-					fmt.Sprintf("http://%s:%s", cortx_endpoint_host, cortx_endpoint_port),
-				),
+				Credentials:      credentials.NewStaticCredentials(cortx_access_key, cortx_secret_access_key, ""),
+				Endpoint:         aws.String(endpoint),
 				Region:           aws.String(cortx_region),
 				DisableSSL:       aws.Bool(true), // Hardcode - Require SSL to be OFF for CORTX!
 				S3ForcePathStyle: aws.Bool(true), // Hardcode - Require PathStyle for CORTX!
@@ -98,7 +122,22 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		}
 
 		// Create New client
-		client := s3.New(sess)
+		client := &CortxClient{
+			S3:       s3.New(sess),
+			Endpoint: endpoint,
+		}
+
+		// Audit Log - Identity is the access key ID, never the secret
+		if v, ok := d.GetOk("audit_log_path"); ok {
+			if client.AuditLog, err = NewAuditLog(v.(string), endpoint, cortx_access_key); err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Unable to open CORTX provider audit log",
+					Detail:   fmt.Sprintf("[ERROR] %v", err),
+				})
+				return nil, diags
+			}
+		}
 
 		return client, diags
 	}
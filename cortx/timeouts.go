@@ -1,10 +1,16 @@
 package cortx
 
-import "time"
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"time"
+)
 
 const (
 	bucketCreatedTimeout                          = 2 * time.Minute
+	bucketUpdateTimeout                           = 2 * time.Minute
+	bucketDeleteTimeout                           = 60 * time.Minute
 	bucketVersioningStableTimeout                 = 1 * time.Minute
+	expirySweepTimeout                            = 20 * time.Minute
 	propagationTimeout                            = 1 * time.Minute
 	lifecycleConfigurationExtraRetryDelay         = 5 * time.Second
 	lifecycleConfigurationRulesPropagationTimeout = 3 * time.Minute
@@ -12,3 +18,11 @@ const (
 	lifecycleConfigurationRulesStatusReady        = "READY"
 	lifecycleConfigurationRulesStatusNotReady     = "NOT_READY"
 )
+
+// applyTimeout - The configured timeout of the running apply, Update also finishes a Create
+func applyTimeout(d *schema.ResourceData) time.Duration {
+	if d.IsNewResource() {
+		return d.Timeout(schema.TimeoutCreate)
+	}
+	return d.Timeout(schema.TimeoutUpdate)
+}
@@ -0,0 +1,123 @@
+package cortx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// forceDestroyPreviewLimit - Number of object versions counted for the force_destroy preview
+// before reporting a lower bound
+const forceDestroyPreviewLimit = 10000
+
+// errStopPaging - Returned from a page callback to stop paging early w/o reporting an error
+var errStopPaging = errors.New("stop paging")
+
+// bucketUsage is the contents of a bucket (or a prefix of a bucket) as seen by ListObjectVersions.
+type bucketUsage struct {
+	Objects         int64 // Current versions, excluding delete markers
+	Versions        int64 // All versions, including current and noncurrent
+	DeleteMarkers   int64
+	Bytes           int64 // Size of all versions
+	NoncurrentBytes int64 // Size of noncurrent versions
+	Truncated       bool  // Counting stopped at the limit, the counts are lower bounds
+}
+
+// add -
+func (u *bucketUsage) add(page *s3.ListObjectVersionsOutput) {
+
+	for _, v := range page.Versions {
+		size := aws.Int64Value(v.Size)
+
+		u.Versions++
+		u.Bytes += size
+
+		if aws.BoolValue(v.IsLatest) {
+			u.Objects++
+		} else {
+			u.NoncurrentBytes += size
+		}
+	}
+
+	u.DeleteMarkers += int64(len(page.DeleteMarkers))
+}
+
+// addUsage -
+func (u *bucketUsage) addUsage(other *bucketUsage) {
+	u.Objects += other.Objects
+	u.Versions += other.Versions
+	u.DeleteMarkers += other.DeleteMarkers
+	u.Bytes += other.Bytes
+	u.NoncurrentBytes += other.NoncurrentBytes
+	u.Truncated = u.Truncated || other.Truncated
+}
+
+// String -
+func (u *bucketUsage) String() string {
+
+	var approx string
+	if u.Truncated {
+		approx = "at least "
+	}
+
+	return fmt.Sprintf(
+		"%s%d objects, %d versions (%d delete markers), %d bytes",
+		approx, u.Objects, u.Versions, u.DeleteMarkers, u.Bytes,
+	)
+}
+
+// measureBucketUsage counts the object versions under `prefix`. With a positive `limit` counting stops (at a page
+// boundary) once `limit` versions have been seen, and the usage is marked as truncated.
+func measureBucketUsage(ctx context.Context, client *s3.S3, bucket string, prefix string, limit int64) (*bucketUsage, error) {
+
+	usage := &bucketUsage{}
+
+	_, err := forEachObjectVersionsPage(ctx, client, bucket, prefix, func(ctx context.Context, client *s3.S3, bucket string, page *s3.ListObjectVersionsOutput) (int64, error) {
+		usage.add(page)
+
+		if limit > 0 && usage.Versions >= limit && aws.BoolValue(page.IsTruncated) {
+			usage.Truncated = true
+			return 0, errStopPaging
+		}
+
+		return 0, nil
+	})
+
+	if err != nil && !errors.Is(err, errStopPaging) {
+		return usage, err
+	}
+
+	return usage, nil
+}
+
+// measureBucketRootUsage counts the object versions at the top level of the bucket (keys w/o a `/`) and returns the
+// top-level prefixes, so that the rest of the bucket can be measured one prefix at a time.
+func measureBucketRootUsage(ctx context.Context, client *s3.S3, bucket string) (*bucketUsage, []string, error) {
+
+	usage := &bucketUsage{}
+
+	var prefixes []string
+
+	input := &s3.ListObjectVersionsInput{
+		Bucket:    aws.String(bucket),
+		Delimiter: aws.String("/"),
+	}
+
+	err := client.ListObjectVersionsPagesWithContext(ctx, input, func(page *s3.ListObjectVersionsOutput, lastPage bool) bool {
+		if page == nil {
+			return !lastPage
+		}
+
+		usage.add(page)
+
+		for _, p := range page.CommonPrefixes {
+			prefixes = append(prefixes, aws.StringValue(p.Prefix))
+		}
+
+		return !lastPage
+	})
+
+	return usage, prefixes, err
+}
@@ -0,0 +1,249 @@
+package cortx
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/hashicorp/aws-sdk-go-base/v2/awsv1shim/v2/tfawserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"net/http"
+	"reflect"
+	"time"
+)
+
+//
+// NOTE: Status & Wait Functions Adapted From the AWS Provider, see:
+// https://github.com/hashicorp/terraform-provider-aws/blob/main/internal/service/s3/wait.go
+//
+
+// lifecycleConfigurationRulesStatus - Reports READY once every expected rule is visible from
+// GetBucketLifecycleConfiguration w. the expected status, NOT_READY otherwise
+func lifecycleConfigurationRulesStatus(ctx context.Context, client *s3.S3, bucket string, rules []*s3.LifecycleRule) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+
+		output, err := client.GetBucketLifecycleConfigurationWithContext(ctx, &s3.GetBucketLifecycleConfigurationInput{
+			Bucket: aws.String(bucket),
+		})
+
+		// Not Yet Visible - Counted against NotFoundChecks
+		if tfawserr.ErrCodeEquals(err, ErrCodeNoSuchLifecycleConfiguration, s3.ErrCodeNoSuchBucket) {
+			return nil, "", nil
+		}
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		for _, expectedRule := range rules {
+			found := false
+
+			for _, actualRule := range output.Rules {
+				if aws.StringValue(actualRule.ID) != aws.StringValue(expectedRule.ID) {
+					continue
+				}
+
+				found = true
+
+				if aws.StringValue(actualRule.Status) != aws.StringValue(expectedRule.Status) {
+					return output, lifecycleConfigurationRulesStatusNotReady, nil
+				}
+			}
+
+			if !found {
+				return output, lifecycleConfigurationRulesStatusNotReady, nil
+			}
+		}
+
+		return output, lifecycleConfigurationRulesStatusReady, nil
+	}
+}
+
+// waitForLifecycleConfigurationRulesStatus - Blocks until the submitted lifecycle rules are
+// consistently reflected by the server (READY on several consecutive reads)
+func waitForLifecycleConfigurationRulesStatus(ctx context.Context, client *s3.S3, bucket string, rules []*s3.LifecycleRule, timeout time.Duration) error {
+
+	stateConf := &resource.StateChangeConf{
+		Pending:                   []string{"", lifecycleConfigurationRulesStatusNotReady},
+		Target:                    []string{lifecycleConfigurationRulesStatusReady},
+		Refresh:                   lifecycleConfigurationRulesStatus(ctx, client, bucket, rules),
+		Timeout:                   timeout,
+		MinTimeout:                10 * time.Second,
+		ContinuousTargetOccurence: 3,
+		NotFoundChecks:            20,
+	}
+
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("waiting for S3 Bucket (%s) lifecycle configuration rules: %w", bucket, err)
+	}
+
+	return nil
+}
+
+// bucketConsistentObservations - Number of consecutive matching reads required before a bucket
+// change is considered visible, on a multi-node cluster each read may be served by another node
+const bucketConsistentObservations = 3
+
+// bucketConsistencyPollInterval - Reads are evenly spaced (not backed off) s.t. the consecutive
+// observations span a predictable window
+var bucketConsistencyPollInterval = 1 * time.Second
+
+const (
+	bucketConsistencyStatusConsistent = "CONSISTENT"
+	bucketConsistencyStatusPending    = "PENDING"
+)
+
+// bucketCreatedStatus - Reports CONSISTENT when HeadBucket, GetBucketVersioning and GetBucketTagging
+// all find the bucket, the follow-up config calls of a create hit each of them. APIs the server
+// doesn't implement are skipped. The (non-nil) output is returned on NoSuchBucket s.t. it resets
+// the consecutive count rather than counting as not found
+func bucketCreatedStatus(ctx context.Context, client *s3.S3, bucket string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+
+		output, err := client.HeadBucketWithContext(ctx, &s3.HeadBucketInput{
+			Bucket: aws.String(bucket),
+		})
+
+		if tfawserr.ErrCodeEquals(err, s3.ErrCodeNoSuchBucket) || tfawserr.ErrStatusCodeEquals(err, http.StatusNotFound) {
+			return output, bucketConsistencyStatusPending, nil
+		}
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		_, err = client.GetBucketVersioningWithContext(ctx, &s3.GetBucketVersioningInput{
+			Bucket: aws.String(bucket),
+		})
+
+		if tfawserr.ErrCodeEquals(err, s3.ErrCodeNoSuchBucket) {
+			return output, bucketConsistencyStatusPending, nil
+		}
+
+		if err != nil && !NotSupportedByServer(err) {
+			return nil, "", err
+		}
+
+		_, err = client.GetBucketTaggingWithContext(ctx, &s3.GetBucketTaggingInput{
+			Bucket: aws.String(bucket),
+		})
+
+		if tfawserr.ErrCodeEquals(err, s3.ErrCodeNoSuchBucket) {
+			return output, bucketConsistencyStatusPending, nil
+		}
+
+		if err != nil && !tfawserr.ErrCodeEquals(err, ErrCodeNoSuchTagSet) && !NotSupportedByServer(err) {
+			return nil, "", err
+		}
+
+		return output, bucketConsistencyStatusConsistent, nil
+	}
+}
+
+// bucketVersioningStatus - Reports CONSISTENT when GetBucketVersioning matches the configuration
+// that was put, only fields set on `expected` are compared
+func bucketVersioningStatus(ctx context.Context, client *s3.S3, bucket string, expected *s3.VersioningConfiguration) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+
+		output, err := client.GetBucketVersioningWithContext(ctx, &s3.GetBucketVersioningInput{
+			Bucket: aws.String(bucket),
+		})
+
+		if tfawserr.ErrCodeEquals(err, s3.ErrCodeNoSuchBucket) {
+			return output, bucketConsistencyStatusPending, nil
+		}
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		if expected.Status != nil && aws.StringValue(output.Status) != aws.StringValue(expected.Status) {
+			return output, bucketConsistencyStatusPending, nil
+		}
+
+		// Servers commonly omit MFADelete when it's disabled
+		if aws.StringValue(expected.MFADelete) == s3.MFADeleteEnabled && aws.StringValue(output.MFADelete) != s3.MFADeleteStatusEnabled {
+			return output, bucketConsistencyStatusPending, nil
+		}
+
+		return output, bucketConsistencyStatusConsistent, nil
+	}
+}
+
+// bucketTagsStatus - Reports CONSISTENT when GetBucketTagging returns exactly `expected`
+func bucketTagsStatus(ctx context.Context, client *s3.S3, bucket string, expected map[string]interface{}) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+
+		output, err := client.GetBucketTaggingWithContext(ctx, &s3.GetBucketTaggingInput{
+			Bucket: aws.String(bucket),
+		})
+
+		if tfawserr.ErrCodeEquals(err, s3.ErrCodeNoSuchBucket) {
+			return output, bucketConsistencyStatusPending, nil
+		}
+
+		if err != nil && !tfawserr.ErrCodeEquals(err, ErrCodeNoSuchTagSet) {
+			return nil, "", err
+		}
+
+		actual := map[string]interface{}{}
+		for _, t := range output.TagSet {
+			actual[aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+		}
+
+		if !reflect.DeepEqual(actual, expected) {
+			return output, bucketConsistencyStatusPending, nil
+		}
+
+		return output, bucketConsistencyStatusConsistent, nil
+	}
+}
+
+// waitForBucketCreated - Blocks until a newly created bucket is found by HeadBucket,
+// GetBucketVersioning and GetBucketTagging on several consecutive reads
+func waitForBucketCreated(ctx context.Context, client *s3.S3, bucket string, timeout time.Duration) error {
+
+	if err := waitForBucketConsistent(ctx, bucketCreatedStatus(ctx, client, bucket), timeout); err != nil {
+		return fmt.Errorf("waiting for S3 Bucket (%s) to be created: %w", bucket, err)
+	}
+
+	return nil
+}
+
+// waitForBucketVersioning -
+func waitForBucketVersioning(ctx context.Context, client *s3.S3, bucket string, expected *s3.VersioningConfiguration, timeout time.Duration) error {
+
+	if err := waitForBucketConsistent(ctx, bucketVersioningStatus(ctx, client, bucket, expected), timeout); err != nil {
+		return fmt.Errorf("waiting for S3 Bucket (%s) versioning: %w", bucket, err)
+	}
+
+	return nil
+}
+
+// waitForBucketTags -
+func waitForBucketTags(ctx context.Context, client *s3.S3, bucket string, expected map[string]interface{}, timeout time.Duration) error {
+
+	if err := waitForBucketConsistent(ctx, bucketTagsStatus(ctx, client, bucket, expected), timeout); err != nil {
+		return fmt.Errorf("waiting for S3 Bucket (%s) tags: %w", bucket, err)
+	}
+
+	return nil
+}
+
+// waitForBucketConsistent - Requires `bucketConsistentObservations` consecutive CONSISTENT reads,
+// a PENDING read in between starts the count over
+func waitForBucketConsistent(ctx context.Context, refresh resource.StateRefreshFunc, timeout time.Duration) error {
+
+	stateConf := &resource.StateChangeConf{
+		Pending:                   []string{bucketConsistencyStatusPending},
+		Target:                    []string{bucketConsistencyStatusConsistent},
+		Refresh:                   refresh,
+		Timeout:                   timeout,
+		PollInterval:              bucketConsistencyPollInterval,
+		ContinuousTargetOccurence: bucketConsistentObservations,
+	}
+
+	_, err := stateConf.WaitForStateContext(ctx)
+
+	return err
+}
@@ -0,0 +1,82 @@
+package cortx
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// scriptedConsistencyStatus - Returns `statuses` in order, then PENDING forever
+func scriptedConsistencyStatus(statuses []string, calls *int) func() (interface{}, string, error) {
+	return func() (interface{}, string, error) {
+
+		*calls++
+
+		if *calls > len(statuses) {
+			return struct{}{}, bucketConsistencyStatusPending, nil
+		}
+
+		return struct{}{}, statuses[*calls-1], nil
+	}
+}
+
+func TestWaitForBucketConsistent(t *testing.T) {
+
+	defer func(interval time.Duration) { bucketConsistencyPollInterval = interval }(bucketConsistencyPollInterval)
+	bucketConsistencyPollInterval = time.Millisecond
+
+	const (
+		c = bucketConsistencyStatusConsistent
+		p = bucketConsistencyStatusPending
+	)
+
+	cases := []struct {
+		name          string
+		statuses      []string
+		expectErr     bool
+		expectedCalls int
+	}{
+		{"consistent", []string{c, c, c}, false, 3},
+		{"pending first", []string{p, p, c, c, c}, false, 5},
+		{"pending in between resets the count", []string{c, c, p, c, c, c}, false, 6},
+		{"never enough in a row", []string{c, c, p, c, c, p}, true, 0},
+	}
+
+	for _, tc := range cases {
+		calls := 0
+
+		err := waitForBucketConsistent(context.Background(), scriptedConsistencyStatus(tc.statuses, &calls), 500*time.Millisecond)
+
+		if tc.expectErr != (err != nil) {
+			t.Errorf("%s: expected error: %t, got: %v", tc.name, tc.expectErr, err)
+		}
+
+		if tc.expectedCalls > 0 && calls != tc.expectedCalls {
+			t.Errorf("%s: expected %d reads, got %d", tc.name, tc.expectedCalls, calls)
+		}
+	}
+}
+
+func TestBucketCreatedStatus(t *testing.T) {
+
+	cases := []struct {
+		name     string
+		f        *fakeS3
+		expected string
+	}{
+		{"visible", &fakeS3{}, bucketConsistencyStatusConsistent},
+		{"not yet visible", &fakeS3{hidden: true}, bucketConsistencyStatusPending},
+	}
+
+	for _, tc := range cases {
+		_, status, err := bucketCreatedStatus(context.Background(), newFakeS3Client(t, tc.f), "bucket")()
+
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+
+		if status != tc.expected {
+			t.Errorf("%s: expected %s, got %s", tc.name, tc.expected, status)
+		}
+	}
+}